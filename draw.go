@@ -0,0 +1,165 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"os"
+
+	"github.com/blake-wilson/simplex-optimizer/simplex"
+	"github.com/llgcode/draw2d/draw2dimg"
+)
+
+// defaultImageSize is the width and height in pixels of
+// rendered images
+const defaultImageSize = 850.0
+
+// edgeColors are the colors of the second and third edges of a
+// drawn simplex. The first edge uses the stroke color.
+var edgeColors = []color.RGBA{{
+	0x00, 0xff, 0x00, 0xff,
+}, {
+	0x00, 0x00, 0xff, 0xff,
+}}
+
+// defaultMargin is the width in pixels of the empty border left
+// around a drawn simplex
+const defaultMargin = 25.0
+
+// DrawOptions configures how a simplex is drawn. Start from
+// DefaultDrawOptions and change the fields of interest.
+type DrawOptions struct {
+	// Width and Height are the size of the image in pixels
+	Width, Height int
+	// Margin is the width in pixels of the empty border left
+	// around the simplex
+	Margin float64
+	// FillColor fills the simplex and StrokeColor draws its
+	// first edge. The other edges use edgeColors.
+	FillColor   color.Color
+	StrokeColor color.Color
+	// LineWidth is the width in pixels of the edges
+	LineWidth float64
+	// Background fills the image behind the simplex
+	Background color.Color
+}
+
+// DefaultDrawOptions returns the options used by drawSimplex when
+// none are given
+func DefaultDrawOptions() *DrawOptions {
+	return &DrawOptions{
+		Width:       int(defaultImageSize),
+		Height:      int(defaultImageSize),
+		Margin:      defaultMargin,
+		FillColor:   color.RGBA{0x44, 0xff, 0x44, 0xff},
+		StrokeColor: color.RGBA{0xff, 0x00, 0x00, 0xff},
+		LineWidth:   5,
+		Background:  color.Transparent,
+	}
+}
+
+// drawSimplex renders a 2-D simplex, scaled and centered so that
+// it fits inside the margin, and returns the image. If opts is
+// nil, DefaultDrawOptions are used. Simplexes of any other
+// dimension are ignored and nil is returned; use
+// DrawSimplexProjected or DrawSimplexPCA for those.
+func drawSimplex(s *simplex.Simplex, opts *DrawOptions) *image.RGBA {
+	if s.Dimension != 2 {
+		return nil
+	}
+	if opts == nil {
+		opts = DefaultDrawOptions()
+	}
+
+	rect := image.Rect(0, 0, opts.Width, opts.Height)
+	dest := image.NewRGBA(rect)
+	if opts.Background != nil {
+		draw.Draw(dest, rect, image.NewUniform(opts.Background), image.ZP, draw.Src)
+	}
+	gc := draw2dimg.NewGraphicContext(dest)
+	gc.SetFillColor(opts.FillColor)
+	gc.SetStrokeColor(opts.StrokeColor)
+	gc.SetLineWidth(opts.LineWidth)
+
+	strokeSimplex(gc, fitToImage(s, float64(opts.Width), float64(opts.Height), opts.Margin))
+	return dest
+}
+
+// fitToImage returns the image coordinates of the points of a
+// 2-D simplex, scaled uniformly so that their bounding box fits
+// inside a border of margin pixels and centered in an image of
+// the given width and height. The size is measured on the
+// translated points which are actually drawn, and axes along
+// which the simplex is flat do not limit the scale.
+func fitToImage(s *simplex.Simplex, width, height, margin float64) []*simplex.Point {
+	shifted := s.SubtractMean().TranslateToPositive()
+	sizeX, sizeY := simplexSize(shifted)
+	pxMult := math.Inf(1)
+	if sizeX > 0 {
+		pxMult = math.Min(pxMult, (width-2*margin)/sizeX)
+	}
+	if sizeY > 0 {
+		pxMult = math.Min(pxMult, (height-2*margin)/sizeY)
+	}
+	if math.IsInf(pxMult, 1) {
+		// Every point is the same
+		pxMult = 1
+	}
+	offsetX := (width - sizeX*pxMult) / 2
+	offsetY := (height - sizeY*pxMult) / 2
+
+	imgPoints := make([]*simplex.Point, len(shifted.Points))
+	for i, p := range shifted.Points {
+		imgPoints[i] = translateCoords(p, pxMult)
+		imgPoints[i].Terms[0] += offsetX
+		imgPoints[i].Terms[1] += offsetY
+	}
+	return imgPoints
+}
+
+// strokeSimplex draws the edges between the given image
+// coordinates, giving each edge its own color
+func strokeSimplex(gc *draw2dimg.GraphicContext, imgPoints []*simplex.Point) {
+	start := imgPoints[0]
+	gc.MoveTo(float64(start.Terms[0]), float64(start.Terms[1]))
+	for i, ip := range imgPoints[1:] {
+		gc.LineTo(float64(ip.Terms[0]), float64(ip.Terms[1]))
+		gc.FillStroke()
+		gc.MoveTo(float64(ip.Terms[0]), float64(ip.Terms[1]))
+		gc.SetStrokeColor(edgeColors[i%len(edgeColors)])
+	}
+	// Close the loop
+	gc.LineTo(float64(start.Terms[0]), float64(start.Terms[1]))
+	gc.FillStroke()
+}
+
+// writePNG encodes img as a PNG file at path
+func writePNG(img image.Image, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// simplexSize returns the width and height of a 2-D simplex
+func simplexSize(s *simplex.Simplex) (float64, float64) {
+	lo, hi := s.BoundingBox()
+	return hi.Terms[0] - lo.Terms[0], hi.Terms[1] - lo.Terms[1]
+}
+
+// translateCoords returns a new point holding the first two
+// coordinates of p scaled by stepSize. p is not modified.
+func translateCoords(p *simplex.Point, stepSize float64) *simplex.Point {
+	imgPoint := simplex.NewPoint(2)
+	imgPoint.Terms[0] = p.Terms[0] * stepSize
+	imgPoint.Terms[1] = p.Terms[1] * stepSize
+	return imgPoint
+}
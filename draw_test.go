@@ -0,0 +1,124 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+	"github.com/blake-wilson/simplex-optimizer/simplex"
+)
+
+func TestDrawSimplex(t *testing.T) {
+	points := []*simplex.Point{{
+		Dims:  2,
+		Terms: []float64{0, 0},
+	}, {
+		Dims:  2,
+		Terms: []float64{10, 20},
+	}, {
+		Dims:  2,
+		Terms: []float64{20, 10},
+	}}
+	s := simplex.NewSimplex(2)
+	s.Points = points
+	img := drawSimplex(s, nil)
+	assert.NotNil(t, img)
+	size := int(defaultImageSize)
+	assert.Equal(t, image.Rect(0, 0, size, size), img.Bounds())
+}
+
+func TestDrawSimplexHigherDimensions(t *testing.T) {
+	// Drawing is only supported for 2-D simplexes
+	s := simplex.NewSimplex(3)
+	s.Points = []*simplex.Point{simplex.NewPoint(3)}
+	assert.Nil(t, drawSimplex(s, nil))
+
+	// A 1-D simplex is skipped too
+	s = simplex.NewSimplex(1)
+	s.Points = []*simplex.Point{simplex.NewPoint(1), simplex.NewPoint(1)}
+	assert.Nil(t, drawSimplex(s, nil))
+}
+
+func TestDrawSimplexLeavesPointsUnchanged(t *testing.T) {
+	s := simplex.NewSimplex(2)
+	s.Points = []*simplex.Point{
+		{Dims: 2, Terms: []float64{1, 2}},
+		{Dims: 2, Terms: []float64{-3, 4}},
+		{Dims: 2, Terms: []float64{5, -6}},
+	}
+	expected := make([][]float64, len(s.Points))
+	for i, p := range s.Points {
+		expected[i] = append([]float64(nil), p.Terms...)
+	}
+	drawSimplex(s, nil)
+	for i, p := range s.Points {
+		assert.Equal(t, expected[i], p.Terms)
+	}
+}
+
+func TestTranslateCoords(t *testing.T) {
+	p := &simplex.Point{Dims: 2, Terms: []float64{1.5, -2}}
+	ip := translateCoords(p, 2)
+	assert.Equal(t, []float64{3, -4}, ip.Terms)
+	assert.Equal(t, []float64{1.5, -2}, p.Terms)
+}
+
+func TestFitToImage(t *testing.T) {
+	// A simplex much larger than the image and far from the
+	// origin
+	s := simplex.NewSimplex(2)
+	s.Points = []*simplex.Point{
+		{Dims: 2, Terms: []float64{1e6, -3e6}},
+		{Dims: 2, Terms: []float64{5e6, 2e6}},
+		{Dims: 2, Terms: []float64{-4e6, 0}},
+	}
+	margin := 40.0
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, p := range fitToImage(s, defaultImageSize, defaultImageSize, margin) {
+		x, y := p.Terms[0], p.Terms[1]
+		assert.True(t, x >= margin-1e-9 && x <= defaultImageSize-margin+1e-9)
+		assert.True(t, y >= margin-1e-9 && y <= defaultImageSize-margin+1e-9)
+		minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+		minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+	}
+	// The wider axis fills the available space and both are
+	// centered
+	assert.InDelta(t, margin, minX, 1e-9)
+	assert.InDelta(t, defaultImageSize-margin, maxX, 1e-9)
+	assert.InDelta(t, defaultImageSize/2, (minY+maxY)/2, 1e-9)
+
+	// A flat simplex is centered rather than scaled infinitely
+	s.Points = []*simplex.Point{
+		{Dims: 2, Terms: []float64{0, 7}},
+		{Dims: 2, Terms: []float64{10, 7}},
+		{Dims: 2, Terms: []float64{20, 7}},
+	}
+	for _, p := range fitToImage(s, defaultImageSize, defaultImageSize, margin) {
+		assert.InDelta(t, defaultImageSize/2, p.Terms[1], 1e-9)
+	}
+	opts := DefaultDrawOptions()
+	opts.Margin = 0
+	assert.NotNil(t, drawSimplex(s, opts))
+}
+
+func TestDrawSimplexOptions(t *testing.T) {
+	s := simplex.NewSimplex(2)
+	s.Points = []*simplex.Point{
+		{Dims: 2, Terms: []float64{0, 0}},
+		{Dims: 2, Terms: []float64{10, 20}},
+		{Dims: 2, Terms: []float64{20, 10}},
+	}
+	background := color.RGBA{0x12, 0x34, 0x56, 0xff}
+	opts := DefaultDrawOptions()
+	opts.Width = 200
+	opts.Height = 200
+	opts.Background = background
+	img := drawSimplex(s, opts)
+	assert.Equal(t, image.Rect(0, 0, 200, 200), img.Bounds())
+	// The corners lie inside the margin, so show the background
+	assert.Equal(t, background, img.RGBAAt(0, 0))
+	assert.Equal(t, background, img.RGBAAt(199, 199))
+}
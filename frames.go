@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"path/filepath"
+
+	"github.com/blake-wilson/simplex-optimizer/simplex"
+	"github.com/llgcode/draw2d/draw2dimg"
+)
+
+// writeSimplexFrames writes each 2-D simplex in frames, such as
+// those parsed from a trace, to its own PNG file in dir, named
+// frame_000.png, frame_001.png and so on. Every frame is drawn
+// over the same box so that frames can be compared: bounds if
+// it is not nil, or else the box returned by framesBounds,
+// which holds every frame.
+func writeSimplexFrames(frames []*simplex.Simplex, dir string, bounds [][2]float64) error {
+	if len(frames) == 0 {
+		return fmt.Errorf(`writeSimplexFrames: no frames`)
+	}
+	for i, f := range frames {
+		if f.Dimension != 2 {
+			return fmt.Errorf(`writeSimplexFrames: frame %d has %d dimensions, expected 2`,
+				i, f.Dimension)
+		}
+	}
+	if bounds == nil {
+		bounds = framesBounds(frames)
+	}
+	for i, f := range frames {
+		path := filepath.Join(dir, fmt.Sprintf(`frame_%03d.png`, i))
+		if err := writePNG(drawSimplexInBounds(f, bounds), path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// framesBounds returns the bounding box of the points of every
+// 2-D simplex in frames, padded by heatmapPadding on each side
+func framesBounds(frames []*simplex.Simplex) [][2]float64 {
+	all := simplex.NewSimplex(2)
+	for _, f := range frames {
+		all.Points = append(all.Points, f.Points...)
+	}
+	return paddedBounds(all)
+}
+
+// drawSimplexInBounds renders a 2-D simplex scaled so that the
+// box bounds, rather than the simplex, fills the image, with the
+// lower corner of the box at the origin
+func drawSimplexInBounds(s *simplex.Simplex, bounds [][2]float64) *image.RGBA {
+	opts := DefaultDrawOptions()
+	size := int(defaultImageSize)
+	rect := image.Rect(0, 0, size, size)
+	dest := image.NewRGBA(rect)
+	draw.Draw(dest, rect, image.White, image.ZP, draw.Src)
+	gc := draw2dimg.NewGraphicContext(dest)
+	gc.SetFillColor(opts.FillColor)
+	gc.SetStrokeColor(opts.StrokeColor)
+	gc.SetLineWidth(opts.LineWidth)
+
+	strokeSimplex(gc, boundsToImage(s.Points, bounds))
+	return dest
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+	"github.com/blake-wilson/simplex-optimizer/simplex"
+)
+
+func TestWriteSimplexFrames(t *testing.T) {
+	eval := func(p *simplex.Point) float64 {
+		return math.Pow(p.Terms[0]-3, 2) + math.Pow(p.Terms[1]-4, 2)
+	}
+	dir, err := ioutil.TempDir(``, `simplex`)
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var trace bytes.Buffer
+	opts := simplex.DefaultOptions()
+	opts.Rand = rand.New(rand.NewSource(1))
+	opts.MaxIters = 10
+	opts.FTol = 0
+	opts.TraceWriter = &trace
+	result, err := simplex.OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+	frames, err := simplex.ParseSimplexTrace(&trace)
+	assert.NoError(t, err)
+
+	// The initial simplex has a frame too
+	assert.NoError(t, writeSimplexFrames(frames, dir, nil))
+	assert.Equal(t, result.Iterations+1, len(frames))
+	for i := range frames {
+		f, err := os.Open(filepath.Join(dir, fmt.Sprintf(`frame_%03d.png`, i)))
+		assert.NoError(t, err)
+		img, err := png.Decode(f)
+		f.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, int(defaultImageSize), img.Bounds().Dx())
+	}
+	_, err = os.Stat(filepath.Join(dir, fmt.Sprintf(`frame_%03d.png`, len(frames))))
+	assert.True(t, os.IsNotExist(err))
+
+	// Every vertex of every frame lies inside the shared box
+	for _, f := range frames {
+		for _, ip := range boundsToImage(f.Points, framesBounds(frames)) {
+			for _, v := range ip.Terms {
+				assert.True(t, v >= 0 && v <= defaultImageSize)
+			}
+		}
+	}
+
+	assert.Error(t, writeSimplexFrames(frames, filepath.Join(dir, `missing`), nil))
+	assert.Error(t, writeSimplexFrames(nil, dir, nil))
+	assert.Error(t, writeSimplexFrames([]*simplex.Simplex{simplex.NewSimplex(3)}, dir, nil))
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"os"
+
+	"github.com/blake-wilson/simplex-optimizer/simplex"
+	"github.com/llgcode/draw2d/draw2dimg"
+)
+
+// writeSimplexGIF renders each 2-D simplex in frames as one
+// frame of an animated GIF written to path, with delay
+// hundredths of a second between frames. The scale and origin
+// are computed from the bounding box of every frame so that
+// the simplex moves across a fixed background rather than
+// being re-centered in each frame.
+func writeSimplexGIF(frames []*simplex.Simplex, path string, delay int) error {
+	if len(frames) == 0 {
+		return fmt.Errorf(`writeSimplexGIF: no frames`)
+	}
+	// Gather the points of every frame into one simplex so
+	// the scaling helpers see the whole run at once
+	all := simplex.NewSimplex(2)
+	for i, f := range frames {
+		if f.Dimension != 2 {
+			return fmt.Errorf(`writeSimplexGIF: frame %d has %d dimensions, expected 2`,
+				i, f.Dimension)
+		}
+		all.Points = append(all.Points, f.Points...)
+	}
+	allPoints := fitToImage(all, defaultImageSize, defaultImageSize, defaultMargin)
+
+	anim := &gif.GIF{}
+	rect := image.Rect(0, 0, int(defaultImageSize), int(defaultImageSize))
+	offset := 0
+	for _, f := range frames {
+		dest := image.NewRGBA(rect)
+		draw.Draw(dest, rect, image.White, image.ZP, draw.Src)
+		gc := draw2dimg.NewGraphicContext(dest)
+		gc.SetFillColor(color.RGBA{0x44, 0xff, 0x44, 0xff})
+		gc.SetStrokeColor(color.RGBA{0xff, 0x00, 0x00, 0xff})
+		gc.SetLineWidth(5)
+
+		strokeSimplex(gc, allPoints[offset:offset+len(f.Points)])
+		offset += len(f.Points)
+
+		frame := image.NewPaletted(rect, palette.Plan9)
+		draw.Draw(frame, rect, dest, image.ZP, draw.Src)
+		anim.Image = append(anim.Image, frame)
+		anim.Delay = append(anim.Delay, delay)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := gif.EncodeAll(f, anim); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"image/gif"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+	"github.com/blake-wilson/simplex-optimizer/simplex"
+)
+
+func TestWriteSimplexGIF(t *testing.T) {
+	eval := func(p *simplex.Point) float64 {
+		return math.Pow(p.Terms[0]-3, 2) + math.Pow(p.Terms[1]-4, 2)
+	}
+	var trace bytes.Buffer
+	opts := simplex.DefaultOptions()
+	opts.Rand = rand.New(rand.NewSource(1))
+	opts.MaxIters = 5
+	opts.FTol = 0
+	opts.TraceWriter = &trace
+	_, err := simplex.OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+	frames, err := simplex.ParseSimplexTrace(&trace)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, len(frames))
+
+	dir, err := ioutil.TempDir(``, `simplex`)
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, `simplex.gif`)
+	assert.NoError(t, writeSimplexGIF(frames, path, 20))
+
+	f, err := os.Open(path)
+	assert.NoError(t, err)
+	defer f.Close()
+	anim, err := gif.DecodeAll(f)
+	assert.NoError(t, err)
+	assert.Equal(t, len(frames), len(anim.Image))
+	for _, d := range anim.Delay {
+		assert.Equal(t, 20, d)
+	}
+}
+
+func TestWriteSimplexGIFInvalid(t *testing.T) {
+	assert.Error(t, writeSimplexGIF(nil, `unused.gif`, 10))
+	assert.Error(t, writeSimplexGIF([]*simplex.Simplex{simplex.NewSimplex(3)}, `unused.gif`, 10))
+}
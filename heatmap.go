@@ -0,0 +1,121 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/blake-wilson/simplex-optimizer/simplex"
+	"github.com/llgcode/draw2d/draw2dimg"
+)
+
+// heatmapPadding is the fraction of the simplex's width and
+// height added on each side when the sampled region is derived
+// from the simplex's bounding box
+const heatmapPadding = 0.5
+
+// drawSimplexHeatmap renders a 2-D simplex over a heatmap of eval.
+// eval is sampled once at the center of every pixel, so this costs
+// defaultImageSize² evaluations. bounds gives the [min, max] range
+// sampled along each axis; if it is nil the range is the simplex's
+// bounding box padded by heatmapPadding on each side. Low costs are
+// drawn blue and high costs red. Simplexes of any other dimension
+// are ignored and nil is returned.
+func drawSimplexHeatmap(s *simplex.Simplex, eval func(*simplex.Point) float64,
+	bounds [][2]float64) *image.RGBA {
+	if s.Dimension != 2 {
+		return nil
+	}
+	if bounds == nil {
+		bounds = paddedBounds(s)
+	}
+	minX, minY := bounds[0][0], bounds[1][0]
+	pxMult := boundsScale(bounds)
+
+	size := int(defaultImageSize)
+	costs := make([]float64, size*size)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	p := simplex.NewPoint(2)
+	for py := 0; py < size; py++ {
+		for px := 0; px < size; px++ {
+			p.Terms[0] = minX + (float64(px)+0.5)/pxMult
+			p.Terms[1] = minY + (float64(py)+0.5)/pxMult
+			c := eval(p)
+			costs[py*size+px] = c
+			if math.IsNaN(c) || math.IsInf(c, 0) {
+				continue
+			}
+			lo = math.Min(lo, c)
+			hi = math.Max(hi, c)
+		}
+	}
+
+	dest := image.NewRGBA(image.Rect(0, 0, size, size))
+	for i, c := range costs {
+		dest.Set(i%size, i/size, heatColor(c, lo, hi))
+	}
+
+	gc := draw2dimg.NewGraphicContext(dest)
+	gc.SetFillColor(color.RGBA{0x44, 0xff, 0x44, 0xff})
+	gc.SetStrokeColor(color.RGBA{0xff, 0x00, 0x00, 0xff})
+	gc.SetLineWidth(5)
+
+	strokeSimplex(gc, boundsToImage(s.Points, bounds))
+	return dest
+}
+
+// boundsScale returns the number of pixels per unit at which the
+// box bounds fits inside an image defaultImageSize pixels wide
+// and high
+func boundsScale(bounds [][2]float64) float64 {
+	return math.Min(defaultImageSize/(bounds[0][1]-bounds[0][0]),
+		defaultImageSize/(bounds[1][1]-bounds[1][0]))
+}
+
+// boundsToImage returns the image coordinates of 2-D points
+// drawn so that the box bounds fills an image defaultImageSize
+// pixels wide and high, with the lower corner of the box at the
+// origin
+func boundsToImage(points []*simplex.Point, bounds [][2]float64) []*simplex.Point {
+	minX, minY := bounds[0][0], bounds[1][0]
+	pxMult := boundsScale(bounds)
+	imgPoints := make([]*simplex.Point, len(points))
+	for i, p := range points {
+		shifted := &simplex.Point{
+			Dims:  2,
+			Terms: []float64{p.Terms[0] - minX, p.Terms[1] - minY},
+		}
+		imgPoints[i] = translateCoords(shifted, pxMult)
+	}
+	return imgPoints
+}
+
+// paddedBounds returns the bounding box of a 2-D simplex widened
+// by heatmapPadding of its size on each side. Axes along which the
+// simplex is flat are given a width of 1.
+func paddedBounds(s *simplex.Simplex) [][2]float64 {
+	lower, upper := s.BoundingBox()
+	bounds := make([][2]float64, 2)
+	for d := range bounds {
+		lo, hi := lower.Terms[d], upper.Terms[d]
+		pad := heatmapPadding * (hi - lo)
+		if pad == 0 {
+			pad = 0.5
+		}
+		bounds[d] = [2]float64{lo - pad, hi + pad}
+	}
+	return bounds
+}
+
+// heatColor maps c, normalized to [lo, hi], onto a blue to red
+// ramp. Costs that are not finite are drawn black.
+func heatColor(c, lo, hi float64) color.RGBA {
+	if math.IsNaN(c) || math.IsInf(c, 0) {
+		return color.RGBA{0x00, 0x00, 0x00, 0xff}
+	}
+	t := 0.0
+	if hi > lo {
+		t = (c - lo) / (hi - lo)
+	}
+	return color.RGBA{uint8(255 * t), 0x00, uint8(255 * (1 - t)), 0xff}
+}
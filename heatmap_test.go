@@ -0,0 +1,80 @@
+package main
+
+import (
+	"image/png"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+	"github.com/blake-wilson/simplex-optimizer/simplex"
+)
+
+func TestDrawSimplexHeatmap(t *testing.T) {
+	// sin(v)/v of the distance from the origin, as in main
+	eval := func(p *simplex.Point) float64 {
+		v := math.Sqrt(math.Pow(p.Terms[0], 2)+
+			math.Pow(p.Terms[1], 2)) + math.Nextafter(1.0, 2.0) - 1.0
+		return math.Sin(v) / v
+	}
+	s := simplex.NewSimplex(2)
+	s.Points = []*simplex.Point{
+		{Dims: 2, Terms: []float64{-5, -5}},
+		{Dims: 2, Terms: []float64{5, -2}},
+		{Dims: 2, Terms: []float64{0, 6}},
+	}
+	bounds := [][2]float64{{-10, 10}, {-10, 10}}
+	img := drawSimplexHeatmap(s, eval, bounds)
+	assert.NotNil(t, img)
+
+	// The center of the image is the maximum of sin(v)/v and the
+	// trough at v ≈ 4.5 is a minimum
+	center := int(defaultImageSize) / 2
+	pxMult := defaultImageSize / 20
+	trough := center + int(4.49*pxMult)
+	assert.Equal(t, heatColor(1, 0, 1), img.RGBAAt(center, center))
+	r, _, b, _ := img.RGBAAt(trough, center).RGBA()
+	assert.True(t, b > r)
+
+	dir, err := ioutil.TempDir(``, `heatmap`)
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, `heatmap.png`)
+	assert.NoError(t, writePNG(img, path))
+
+	f, err := os.Open(path)
+	assert.NoError(t, err)
+	defer f.Close()
+	decoded, err := png.Decode(f)
+	assert.NoError(t, err)
+	assert.Equal(t, img.Bounds(), decoded.Bounds())
+}
+
+func TestDrawSimplexHeatmapAutoBounds(t *testing.T) {
+	evals := 0
+	eval := func(p *simplex.Point) float64 {
+		evals++
+		return p.Terms[0] + p.Terms[1]
+	}
+	s := simplex.NewSimplex(2)
+	s.Points = []*simplex.Point{
+		{Dims: 2, Terms: []float64{0, 0}},
+		{Dims: 2, Terms: []float64{2, 0}},
+		{Dims: 2, Terms: []float64{0, 4}},
+	}
+	assert.Equal(t, [][2]float64{{-1, 3}, {-2, 6}}, paddedBounds(s))
+	assert.NotNil(t, drawSimplexHeatmap(s, eval, nil))
+	assert.Equal(t, int(defaultImageSize*defaultImageSize), evals)
+
+	assert.Nil(t, drawSimplexHeatmap(simplex.NewSimplex(3), eval, nil))
+}
+
+func TestHeatColor(t *testing.T) {
+	assert.Equal(t, uint8(0xff), heatColor(0, 0, 10).B)
+	assert.Equal(t, uint8(0xff), heatColor(10, 0, 10).R)
+	assert.Equal(t, uint8(0), heatColor(math.NaN(), 0, 10).B)
+	// A flat objective is drawn with the low color
+	assert.Equal(t, heatColor(0, 0, 10), heatColor(3, 3, 3))
+}
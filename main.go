@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+
+	"github.com/blake-wilson/simplex-optimizer/simplex"
+)
+
+func main() {
+	framesDir := flag.String(`frames`, ``,
+		`directory in which to write a PNG of the simplex at every iteration`)
+	flag.Parse()
+
+	evalFunc := func(p *simplex.Point) float64 {
+		//sum := 0.0
+		//for _, v := range p.Terms {
+		//	sum += v
+		//}
+		// return 10 - p.Terms[0]
+
+		// ex1
+		// return math.Abs(p.Terms[1] - p.Terms[0])
+
+		// ex2
+		// return math.Pow(p.Terms[1]-3, 2) + math.Pow(p.Terms[0]-4, 2)
+
+		// ex3
+		v := math.Sqrt(math.Pow(p.Terms[0], 2)+
+			math.Pow(p.Terms[1], 2)) + math.Nextafter(1.0, 2.0) - 1.0
+		return math.Sin(v) / v
+		//return sum
+	}
+	file, err := os.Create(`simplex.txt`)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	var trace bytes.Buffer
+	opts := simplex.DefaultOptions()
+	opts.TraceWriter = io.MultiWriter(file, &trace)
+	result, err := simplex.OptimizeWithOptions(evalFunc, 2, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("best point is %v with cost %v\n", result.Best, result.BestValue)
+	fmt.Printf("%s after %d iterations and %d evaluations\n",
+		result.StopReason, result.Iterations, result.Evaluations)
+	if img := drawSimplex(result.FinalSimplex, nil); img != nil {
+		if err := writePNG(img, `simplex.png`); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	frames, err := simplex.ParseSimplexTrace(&trace)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := writeSimplexGIF(frames, `simplex.gif`, 50); err != nil {
+		log.Fatal(err)
+	}
+	if *framesDir != `` {
+		if err := writeSimplexFrames(frames, *framesDir, opts.Bounds); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/blake-wilson/simplex-optimizer/simplex"
+)
+
+// pcaIters is the number of power iterations used to find each
+// principal component
+const pcaIters = 100
+
+// DrawSimplexProjected renders a simplex of any dimension by
+// orthogonally projecting its vertices onto the coordinate axes
+// axisX and axisY, i.e. dropping every other coordinate.
+func DrawSimplexProjected(s *simplex.Simplex, axisX, axisY int) (*image.RGBA, error) {
+	projected, err := projectOntoAxes(s, axisX, axisY)
+	if err != nil {
+		return nil, err
+	}
+	return drawSimplex(projected, nil), nil
+}
+
+// DrawSimplexPCA renders a simplex of any dimension by projecting
+// its mean-centered vertices onto their first two principal
+// components, the directions along which the vertices vary most.
+func DrawSimplexPCA(s *simplex.Simplex) (*image.RGBA, error) {
+	projected, err := projectPCA(s)
+	if err != nil {
+		return nil, err
+	}
+	return drawSimplex(projected, nil), nil
+}
+
+// projectOntoAxes returns a 2-D simplex holding coordinates axisX
+// and axisY of each vertex of s. s is not modified.
+func projectOntoAxes(s *simplex.Simplex, axisX, axisY int) (*simplex.Simplex, error) {
+	for _, axis := range []int{axisX, axisY} {
+		if axis < 0 || axis >= s.Dimension {
+			return nil, fmt.Errorf(`projectOntoAxes: axis %d out of range for %d dimensions`,
+				axis, s.Dimension)
+		}
+	}
+	if axisX == axisY {
+		return nil, fmt.Errorf(`projectOntoAxes: axes must differ, got %d twice`, axisX)
+	}
+	projected := simplex.NewSimplex(2)
+	for _, p := range s.Points {
+		projected.Points = append(projected.Points, &simplex.Point{
+			Dims:  2,
+			Terms: []float64{p.Terms[axisX], p.Terms[axisY]},
+		})
+	}
+	return projected, nil
+}
+
+// projectPCA returns a 2-D simplex holding the coordinates of the
+// mean-centered vertices of s along its first two principal
+// components. s is not modified.
+func projectPCA(s *simplex.Simplex) (*simplex.Simplex, error) {
+	if s.Dimension < 2 {
+		return nil, fmt.Errorf(`projectPCA: need at least 2 dimensions, got %d`, s.Dimension)
+	}
+	if len(s.Points) == 0 {
+		return nil, fmt.Errorf(`projectPCA: simplex has no points`)
+	}
+	centered := s.SubtractMean()
+
+	// Covariance (up to a constant factor) of the vertices
+	n := s.Dimension
+	cov := make([][]float64, n)
+	for i := range cov {
+		cov[i] = make([]float64, n)
+		for _, p := range centered.Points {
+			for j := range cov[i] {
+				cov[i][j] += p.Terms[i] * p.Terms[j]
+			}
+		}
+	}
+
+	first := principalComponent(cov, nil)
+	second := principalComponent(cov, first)
+
+	projected := simplex.NewSimplex(2)
+	for _, p := range centered.Points {
+		projected.Points = append(projected.Points, &simplex.Point{
+			Dims:  2,
+			Terms: []float64{dot(p.Terms, first), dot(p.Terms, second)},
+		})
+	}
+	return projected, nil
+}
+
+// principalComponent finds the dominant unit eigenvector of the
+// symmetric matrix cov by power iteration. If orthogonal is not
+// nil the search is restricted to vectors orthogonal to it.
+func principalComponent(cov [][]float64, orthogonal []float64) []float64 {
+	n := len(cov)
+	v := make([]float64, n)
+	for i := range v {
+		// Start away from any axis so that components lying
+		// along a single axis are still found
+		v[i] = 1 / float64(i+1)
+	}
+	for iter := 0; iter < pcaIters; iter++ {
+		if orthogonal != nil {
+			removeComponent(v, orthogonal)
+		}
+		if !normalize(v) {
+			break
+		}
+		next := make([]float64, n)
+		for i := range cov {
+			next[i] = dot(cov[i], v)
+		}
+		if orthogonal != nil {
+			removeComponent(next, orthogonal)
+		}
+		if !normalize(next) {
+			// v is in the null space, so any direction is as
+			// good as another
+			break
+		}
+		v = next
+	}
+	if orthogonal != nil {
+		removeComponent(v, orthogonal)
+	}
+	if !normalize(v) {
+		v = fallbackAxis(n, orthogonal)
+	}
+	return v
+}
+
+// fallbackAxis returns the unit axis least aligned with
+// orthogonal, used when the vertices do not span enough
+// dimensions to define a component
+func fallbackAxis(n int, orthogonal []float64) []float64 {
+	best := 0
+	for i := 1; orthogonal != nil && i < n; i++ {
+		if math.Abs(orthogonal[i]) < math.Abs(orthogonal[best]) {
+			best = i
+		}
+	}
+	v := make([]float64, n)
+	v[best] = 1
+	if orthogonal != nil {
+		removeComponent(v, orthogonal)
+		normalize(v)
+	}
+	return v
+}
+
+// removeComponent subtracts the projection of v onto the unit
+// vector u from v in place
+func removeComponent(v, u []float64) {
+	d := dot(v, u)
+	for i := range v {
+		v[i] -= d * u[i]
+	}
+}
+
+// normalize scales v to unit length in place. It returns false
+// if v is too close to zero to be normalized.
+func normalize(v []float64) bool {
+	length := math.Sqrt(dot(v, v))
+	if length < 1e-12 {
+		return false
+	}
+	for i := range v {
+		v[i] /= length
+	}
+	return true
+}
+
+func dot(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
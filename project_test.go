@@ -0,0 +1,64 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+	"github.com/blake-wilson/simplex-optimizer/simplex"
+)
+
+func simplex4D() *simplex.Simplex {
+	s := simplex.NewSimplex(4)
+	s.Points = []*simplex.Point{
+		{Dims: 4, Terms: []float64{0, 0, 0, 0}},
+		{Dims: 4, Terms: []float64{1, 5, 2, 0}},
+		{Dims: 4, Terms: []float64{3, -1, 0, 1}},
+		{Dims: 4, Terms: []float64{2, 2, 4, 2}},
+		{Dims: 4, Terms: []float64{-1, 0, 1, 3}},
+	}
+	return s
+}
+
+func TestDrawSimplexProjected(t *testing.T) {
+	s := simplex4D()
+	img, err := DrawSimplexProjected(s, 0, 2)
+	assert.NoError(t, err)
+	assert.NotNil(t, img)
+	assert.Equal(t, int(defaultImageSize), img.Bounds().Dx())
+	assert.Equal(t, int(defaultImageSize), img.Bounds().Dy())
+
+	projected, err := projectOntoAxes(s, 0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1, 2}, projected.Points[1].Terms)
+	assert.Equal(t, []float64{2, 4}, projected.Points[3].Terms)
+
+	_, err = DrawSimplexProjected(s, 0, 4)
+	assert.Error(t, err)
+	_, err = DrawSimplexProjected(s, 1, 1)
+	assert.Error(t, err)
+}
+
+func TestDrawSimplexPCA(t *testing.T) {
+	img, err := DrawSimplexPCA(simplex4D())
+	assert.NoError(t, err)
+	assert.NotNil(t, img)
+}
+
+func TestProjectPCA(t *testing.T) {
+	// The vertices vary most along x and then along z, so the
+	// projection recovers those coordinates up to sign
+	s := simplex.NewSimplex(3)
+	s.Points = []*simplex.Point{
+		{Dims: 3, Terms: []float64{-10, 0, 0}},
+		{Dims: 3, Terms: []float64{10, 0, 0}},
+		{Dims: 3, Terms: []float64{0, 0, 2}},
+		{Dims: 3, Terms: []float64{0, 0, -2}},
+	}
+	projected, err := projectPCA(s)
+	assert.NoError(t, err)
+	for i, p := range projected.Points {
+		assert.InDelta(t, math.Abs(s.Points[i].Terms[0]), math.Abs(p.Terms[0]), 1e-9)
+		assert.InDelta(t, math.Abs(s.Points[i].Terms[2]), math.Abs(p.Terms[1]), 1e-9)
+	}
+}
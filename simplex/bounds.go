@@ -0,0 +1,87 @@
+package simplex
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// BoundaryMode controls how points which fall outside of
+// Options.Bounds are brought back inside
+type BoundaryMode int
+
+const (
+	// BoundaryClamp moves each out of bounds coordinate to
+	// the nearest bound
+	BoundaryClamp BoundaryMode = iota
+	// BoundaryReflect mirrors each out of bounds coordinate
+	// back across the bound it crossed
+	BoundaryReflect
+)
+
+func (m BoundaryMode) String() string {
+	switch m {
+	case BoundaryClamp:
+		return `clamp`
+	case BoundaryReflect:
+		return `reflect`
+	}
+	return fmt.Sprintf(`BoundaryMode(%d)`, int(m))
+}
+
+// validateBounds checks that every bound has its lower limit
+// no greater than its upper limit
+func validateBounds(bounds [][2]float64) error {
+	for d, b := range bounds {
+		if b[0] > b[1] {
+			return fmt.Errorf(`Options: bounds for dimension %d are inverted: %v`, d, b)
+		}
+	}
+	return nil
+}
+
+// constrain brings p back inside bounds in place according
+// to mode. p is left unchanged if bounds is empty.
+func constrain(p *Point, bounds [][2]float64, mode BoundaryMode) {
+	for d, b := range bounds {
+		lo, hi := b[0], b[1]
+		v := p.Terms[d]
+		if v >= lo && v <= hi {
+			continue
+		}
+		switch mode {
+		case BoundaryReflect:
+			// Fold the coordinate back and forth across the
+			// box until it lands inside
+			width := hi - lo
+			if width == 0 {
+				v = lo
+				break
+			}
+			t := math.Mod(v-lo, 2*width)
+			if t < 0 {
+				t += 2 * width
+			}
+			if t > width {
+				t = 2*width - t
+			}
+			v = lo + t
+		default:
+			v = math.Max(lo, math.Min(hi, v))
+		}
+		p.Terms[d] = v
+	}
+}
+
+// initPointsInBounds generates count random points whose terms
+// are drawn uniformly from their dimension's bounds using rng
+func initPointsInBounds(rng *rand.Rand, count int, bounds [][2]float64) []*Point {
+	points := make([]*Point, count)
+	for i := 0; i < count; i++ {
+		points[i] = NewPoint(len(bounds))
+		for d, b := range bounds {
+			points[i].Terms[d] = b[0] + rng.Float64()*(b[1]-b[0])
+		}
+	}
+	return points
+}
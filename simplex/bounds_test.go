@@ -0,0 +1,79 @@
+package simplex
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+func TestConstrainClamp(t *testing.T) {
+	bounds := [][2]float64{{0, 10}, {-5, 5}}
+	p := &Point{Dims: 2, Terms: []float64{12, -7}}
+	constrain(p, bounds, BoundaryClamp)
+	assert.Equal(t, []float64{10, -5}, p.Terms)
+
+	p = &Point{Dims: 2, Terms: []float64{3, 4}}
+	constrain(p, bounds, BoundaryClamp)
+	assert.Equal(t, []float64{3, 4}, p.Terms)
+}
+
+func TestConstrainReflect(t *testing.T) {
+	bounds := [][2]float64{{0, 10}, {-5, 5}}
+	p := &Point{Dims: 2, Terms: []float64{12, -7}}
+	constrain(p, bounds, BoundaryReflect)
+	assert.Equal(t, []float64{8, -3}, p.Terms)
+
+	// Points far outside are folded back until they are inside
+	p = &Point{Dims: 2, Terms: []float64{-23, 0}}
+	constrain(p, bounds, BoundaryReflect)
+	assert.Equal(t, []float64{3, 0}, p.Terms)
+}
+
+func TestInitPointsInBounds(t *testing.T) {
+	bounds := [][2]float64{{0, 10}, {-5, 5}}
+	for _, p := range initPointsInBounds(rand.New(rand.NewSource(3)), 10, bounds) {
+		for d, v := range p.Terms {
+			assert.True(t, v >= bounds[d][0] && v <= bounds[d][1])
+		}
+	}
+}
+
+func TestOptimizeBounded(t *testing.T) {
+	// The unconstrained minimum at (-2, 1) lies outside of the
+	// box, so the constrained minimum is pinned at x = 0
+	bounds := [][2]float64{{0, 10}, {-5, 5}}
+	for _, mode := range []BoundaryMode{BoundaryClamp, BoundaryReflect} {
+		eval := func(p *Point) float64 {
+			x, y := p.Terms[0], p.Terms[1]
+			if x < 0 || x > 10 || y < -5 || y > 5 {
+				t.Fatalf(`%v: evaluated out of bounds point %v`, mode, p.Terms)
+			}
+			return math.Pow(x+2, 2) + math.Pow(y-1, 2)
+		}
+		opts := seededOptions(1)
+		opts.Bounds = bounds
+		opts.BoundaryMode = mode
+		opts.FTol = 1e-12
+		opts.MaxIters = 1000
+		result, err := OptimizeWithOptions(eval, 2, opts)
+		assert.NoError(t, err)
+		assert.InDelta(t, 0, result.Best.Terms[0], 1e-3)
+		assert.InDelta(t, 1, result.Best.Terms[1], 1e-3)
+		for _, p := range result.FinalSimplex.Points {
+			assert.True(t, p.Terms[0] >= 0 && p.Terms[0] <= 10)
+			assert.True(t, p.Terms[1] >= -5 && p.Terms[1] <= 5)
+		}
+	}
+}
+
+func TestOptimizeBoundsMismatch(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Bounds = [][2]float64{{0, 1}}
+	_, err := OptimizeWithOptions(func(p *Point) float64 { return 0 }, 2, opts)
+	assert.Error(t, err)
+
+	opts.Bounds = [][2]float64{{0, 1}, {1, 0}}
+	assert.Error(t, opts.Validate())
+}
@@ -0,0 +1,69 @@
+package simplex
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CachedObjective memoizes an Objective. Points whose terms are
+// equal once rounded to a fixed number of decimal digits share
+// one evaluation, so fewer digits trade accuracy for more hits.
+// It is safe for concurrent use if the wrapped Objective is.
+type CachedObjective struct {
+	obj    Objective
+	scale  float64
+	mu     sync.Mutex
+	values map[string]float64
+	hits   int
+}
+
+// NewCachedObjective returns a CachedObjective which rounds the
+// terms of each point to digits decimal digits
+func NewCachedObjective(obj Objective, digits int) *CachedObjective {
+	return &CachedObjective{
+		obj:    obj,
+		scale:  math.Pow(10, float64(digits)),
+		values: make(map[string]float64),
+	}
+}
+
+// Evaluate returns the cached value for p, evaluating the
+// wrapped Objective only if no point rounding to the same terms
+// has been evaluated
+func (c *CachedObjective) Evaluate(p *Point) float64 {
+	key := c.key(p)
+	c.mu.Lock()
+	value, ok := c.values[key]
+	if ok {
+		c.hits++
+	}
+	c.mu.Unlock()
+	if ok {
+		return value
+	}
+	// The lock is not held while evaluating so that concurrent
+	// evaluations of different points are not serialized
+	value = c.obj.Evaluate(p)
+	c.mu.Lock()
+	c.values[key] = value
+	c.mu.Unlock()
+	return value
+}
+
+// Hits returns the number of evaluations answered from the cache
+func (c *CachedObjective) Hits() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits
+}
+
+// key returns the rounded terms of p as a map key
+func (c *CachedObjective) key(p *Point) string {
+	terms := make([]string, len(p.Terms))
+	for d, v := range p.Terms {
+		terms[d] = strconv.FormatFloat(math.Round(v*c.scale)/c.scale, 'g', -1, 64)
+	}
+	return strings.Join(terms, `,`)
+}
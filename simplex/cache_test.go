@@ -0,0 +1,59 @@
+package simplex
+
+import (
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+func TestCachedObjective(t *testing.T) {
+	obj := &countingObjective{}
+	cache := NewCachedObjective(obj, 3)
+	p := &Point{Dims: 2, Terms: []float64{1, 2}}
+
+	first := cache.Evaluate(p)
+	assert.Equal(t, 1, obj.calls)
+	assert.Equal(t, 0, cache.Hits())
+	for i := 1; i <= 3; i++ {
+		assert.Equal(t, first, cache.Evaluate(p.Clone()))
+		assert.Equal(t, i, cache.Hits())
+	}
+	assert.Equal(t, 1, obj.calls)
+
+	// Points which round to the same terms collide
+	cache.Evaluate(&Point{Dims: 2, Terms: []float64{1.0001, 2}})
+	assert.Equal(t, 4, cache.Hits())
+	cache.Evaluate(&Point{Dims: 2, Terms: []float64{1.001, 2}})
+	assert.Equal(t, 4, cache.Hits())
+	assert.Equal(t, 2, obj.calls)
+}
+
+func TestOptimizeCache(t *testing.T) {
+	obj := &countingObjective{}
+	evals := 0
+	eval := func(p *Point) float64 {
+		evals++
+		return obj.Evaluate(p)
+	}
+	initial := []*Point{
+		{Dims: 2, Terms: []float64{0, 0}},
+		{Dims: 2, Terms: []float64{1, 0}},
+		{Dims: 2, Terms: []float64{0, 1}},
+	}
+	opts := DefaultOptions()
+	opts.FTol = 0
+	opts.MaxIters = 50
+	opts.Cache = true
+	// Rounding to whole numbers makes the shrinking simplex
+	// revisit the same keys
+	opts.CacheDigits = 0
+	result, err := OptimizeFrom(eval, initial, opts)
+	assert.NoError(t, err)
+	assert.True(t, result.CacheHits > 0)
+	assert.Equal(t, evals, obj.calls)
+
+	opts.Cache = false
+	result, err = OptimizeFrom(eval, initial, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.CacheHits)
+}
@@ -0,0 +1,104 @@
+package simplex
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CentroidMethod selects how the center through which the worst
+// point is reflected is computed from the other points
+type CentroidMethod int
+
+const (
+	// CentroidMean uses the arithmetic mean of the points, as
+	// in the standard Nelder-Mead method
+	CentroidMean CentroidMethod = iota
+	// CentroidMedian uses the component-wise median of the
+	// points, as computed by ComputeManhattanCentroid
+	CentroidMedian
+	// CentroidCompensatedMean uses the arithmetic mean of the
+	// points, summed as by CompensatedSumPoints. It is slightly
+	// slower than CentroidMean but more accurate when there
+	// are many points or their coordinates are large compared
+	// to their differences.
+	CentroidCompensatedMean
+)
+
+func (m CentroidMethod) String() string {
+	switch m {
+	case CentroidMean:
+		return `mean centroid`
+	case CentroidMedian:
+		return `median centroid`
+	case CentroidCompensatedMean:
+		return `compensated mean centroid`
+	}
+	return fmt.Sprintf(`CentroidMethod(%d)`, int(m))
+}
+
+// ReflectionCenter selects the point through which the worst
+// point is reflected, and from which the expanded and
+// contracted points are then measured
+type ReflectionCenter int
+
+const (
+	// ReflectThroughCentroid uses the centroid of every point
+	// but the worst, computed by Options.CentroidMethod, as in
+	// the standard Nelder-Mead method
+	ReflectThroughCentroid ReflectionCenter = iota
+	// ReflectThroughBest uses the best point, so that the
+	// worst point is moved to the far side of it
+	ReflectThroughBest
+)
+
+func (c ReflectionCenter) String() string {
+	switch c {
+	case ReflectThroughCentroid:
+		return `reflect through centroid`
+	case ReflectThroughBest:
+		return `reflect through best`
+	}
+	return fmt.Sprintf(`ReflectionCenter(%d)`, int(c))
+}
+
+// reflectionCenterInto stores the center selected by
+// opts.ReflectionCenter in dst, which is returned
+func (s *Simplex) reflectionCenterInto(dst *Point, opts *Options) *Point {
+	if opts.ReflectionCenter == ReflectThroughBest {
+		copy(dst.Terms, s.Points[0].Terms)
+		return dst
+	}
+	return s.centroidExcludingWorstInto(dst, opts.CentroidMethod)
+}
+
+// ComputeManhattanCentroid returns the component-wise median of
+// the given points, which minimizes the sum of the Manhattan
+// distances to them. Unlike the mean of ComputeCentroid it is
+// not dragged away by a single distant point. Each median of an
+// even number of terms is the mean of the middle two. It panics
+// if no points are given.
+func ComputeManhattanCentroid(points ...*Point) *Point {
+	if len(points) == 0 {
+		panic(`ComputeManhattanCentroid: ` + ErrNoPoints.Error())
+	}
+	return manhattanCentroidInto(NewPoint(points[0].Dims), points)
+}
+
+// manhattanCentroidInto is like ComputeManhattanCentroid but
+// stores the centroid in dst, which is returned
+func manhattanCentroidInto(dst *Point, points []*Point) *Point {
+	terms := make([]float64, len(points))
+	mid := len(points) / 2
+	for d := range dst.Terms {
+		for i, p := range points {
+			terms[i] = p.Terms[d]
+		}
+		sort.Float64s(terms)
+		if len(terms)%2 == 1 {
+			dst.Terms[d] = terms[mid]
+		} else {
+			dst.Terms[d] = (terms[mid-1] + terms[mid]) / 2
+		}
+	}
+	return dst
+}
@@ -0,0 +1,113 @@
+package simplex
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+func TestComputeManhattanCentroid(t *testing.T) {
+	// Three vertices near the origin and one far away
+	points := []*Point{
+		{Dims: 3, Terms: []float64{0, 0, 0}},
+		{Dims: 3, Terms: []float64{1, 0, 0}},
+		{Dims: 3, Terms: []float64{0, 1, 0}},
+		{Dims: 3, Terms: []float64{1000, 1000, 1000}},
+	}
+	// The outlier drags the mean far from the other vertices,
+	// but the median stays among them
+	assert.Equal(t, []float64{250.25, 250.25, 250}, ComputeCentroid(points...).Terms)
+	assert.Equal(t, []float64{0.5, 0.5, 0}, ComputeManhattanCentroid(points...).Terms)
+	// With an odd number of points the middle term is used
+	assert.Equal(t, []float64{1, 1, 0}, ComputeManhattanCentroid(points[1:]...).Terms)
+	assert.Panics(t, func() { ComputeManhattanCentroid() })
+}
+
+func TestCentroidMethod(t *testing.T) {
+	// A bad outlier which is not the worst point drags the
+	// mean center of reflection far away, but not the median
+	s := NewSimplex(3)
+	s.SetPoint(&Point{Dims: 3, Terms: []float64{0, 0, 0}}, 0)
+	s.SetPoint(&Point{Dims: 3, Terms: []float64{1, 0, 0}}, 1)
+	s.SetPoint(&Point{Dims: 3, Terms: []float64{1000, 1000, 1000}}, 2)
+	s.SetPoint(&Point{Dims: 3, Terms: []float64{0, 0, 1}}, 3)
+	mean := &Point{Dims: 3, Terms: []float64{1001.0 / 3, 1000.0 / 3, 1000.0 / 3}}
+	assert.True(t, mean.ApproxEquals(s.centroidExcludingWorstInto(NewPoint(3), CentroidMean), 1e-9))
+	assert.Equal(t, []float64{1, 0, 0}, s.centroidExcludingWorstInto(NewPoint(3), CentroidMedian).Terms)
+
+	// Step reflects the worst point through the median
+	opts := DefaultOptions()
+	opts.CentroidMethod = CentroidMedian
+	assert.Equal(t, OpReflect, s.Step(func(p *Point) float64 { return 0.5 }, opts))
+	assert.Equal(t, []float64{2, 0, -1}, s.Points[1].Terms)
+
+	opts.CentroidMethod = CentroidCompensatedMean + 1
+	assert.EqualError(t, opts.Validate(), `Options: unknown CentroidMethod(3)`)
+}
+
+func TestCompensatedSumPoints(t *testing.T) {
+	// The ones are lost when added to the large terms one at a
+	// time, so the naive sum is 0
+	var points []*Point
+	for _, v := range []float64{1e16, 1, 1, 1, 1, -1e16} {
+		points = append(points, &Point{Dims: 2, Terms: []float64{v, -v}})
+	}
+	assert.Equal(t, []float64{0, 0}, SumPoints(points...).Terms)
+	assert.Equal(t, []float64{4, -4}, CompensatedSumPoints(points...).Terms)
+
+	assert.Panics(t, func() { CompensatedSumPoints() })
+	assert.Panics(t, func() { CompensatedSumPoints(points[0], NewPoint(3)) })
+}
+
+func TestCentroidCompensatedMean(t *testing.T) {
+	s := NewSimplex(3)
+	s.SetPoint(&Point{Dims: 3, Terms: []float64{1e16, 0, 0}}, 0)
+	s.SetPoint(&Point{Dims: 3, Terms: []float64{1, 0, 0}}, 1)
+	s.SetPoint(&Point{Dims: 3, Terms: []float64{-1e16, 0, 0}}, 2)
+	s.SetPoint(&Point{Dims: 3, Terms: []float64{0, 0, 1}}, 3)
+	assert.Equal(t, []float64{0, 0, 0}, s.centroidExcludingWorstInto(NewPoint(3), CentroidMean).Terms)
+	assert.Equal(t, []float64{1.0 / 3, 0, 0},
+		s.centroidExcludingWorstInto(NewPoint(3), CentroidCompensatedMean).Terms)
+
+	eval := func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-1, 2) + math.Pow(p.Terms[1]+2, 2) + math.Pow(p.Terms[2]-3, 2)
+	}
+	opts := seededOptions(1)
+	opts.CentroidMethod = CentroidCompensatedMean
+	opts.FTol = 1e-12
+	opts.MaxIters = 1000
+	result, err := OptimizeWithOptions(eval, 3, opts)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0, result.BestValue, 1e-6)
+}
+
+func TestReflectThroughBest(t *testing.T) {
+	s := NewSimplex(2)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{1, 1}}, 0)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{3, 1}}, 1)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{1, 4}}, 3)
+
+	// The worst point lands on the far side of the best
+	opts := DefaultOptions()
+	opts.ReflectionCenter = ReflectThroughBest
+	assert.Equal(t, OpReflect, s.Step(func(p *Point) float64 { return 0.5 }, opts))
+	assert.Equal(t, []float64{1, -2}, s.Points[1].Terms)
+
+	eval := func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-1, 2) + 2*math.Pow(p.Terms[1]+2, 2) + p.Terms[0]*p.Terms[1]
+	}
+	opts = seededOptions(1)
+	opts.ReflectionCenter = ReflectThroughBest
+	opts.FTol = 1e-12
+	opts.MaxIters = 2000
+	result, err := OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+	assert.True(t, result.Converged)
+	// The minimum of the quadratic is at (16/7, -18/7)
+	assert.InDelta(t, 16.0/7, result.Best.Terms[0], 1e-4)
+	assert.InDelta(t, -18.0/7, result.Best.Terms[1], 1e-4)
+
+	opts.ReflectionCenter = ReflectThroughBest + 1
+	assert.EqualError(t, opts.Validate(), `Options: unknown ReflectionCenter(2)`)
+}
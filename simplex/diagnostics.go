@@ -0,0 +1,37 @@
+package simplex
+
+// Diagnostics summarizes the state of a simplex for reporting
+type Diagnostics struct {
+	// BestValue and WorstValue are the values of the best and
+	// worst points
+	BestValue, WorstValue float64
+	// StdDev is the standard deviation of the values, as
+	// returned by Simplex.StdDev
+	StdDev float64
+	// Volume is the volume of the simplex, as returned by
+	// Simplex.Volume
+	Volume float64
+	// Diameter is the length of the longest edge, as returned
+	// by Simplex.Diameter
+	Diameter float64
+	// Centroid is the centroid of every point, as returned by
+	// Simplex.Centroid
+	Centroid *Point
+}
+
+// Diagnostics returns the metrics of the simplex in one call.
+// The simplex is not modified. It panics if the simplex fails
+// Validate.
+func (s *Simplex) Diagnostics() Diagnostics {
+	if err := s.Validate(); err != nil {
+		panic(`Diagnostics: ` + err.Error())
+	}
+	return Diagnostics{
+		BestValue:  s.Evaluations[0],
+		WorstValue: s.Evaluations[len(s.Evaluations)-1],
+		StdDev:     s.StdDev(),
+		Volume:     s.Volume(),
+		Diameter:   s.Diameter(),
+		Centroid:   s.Centroid(),
+	}
+}
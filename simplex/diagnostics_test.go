@@ -0,0 +1,29 @@
+package simplex
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+func TestSimplexDiagnostics(t *testing.T) {
+	s := NewSimplex(2)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{0, 0}}, 1)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{4, 0}}, 2)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{0, 3}}, 4)
+	before := s.Clone()
+
+	diag := s.Diagnostics()
+	assert.Equal(t, 1.0, diag.BestValue)
+	assert.Equal(t, 4.0, diag.WorstValue)
+	assert.InDelta(t, math.Sqrt(7.0/3), diag.StdDev, 1e-12)
+	assert.InDelta(t, 6, diag.Volume, 1e-12)
+	assert.InDelta(t, 5, diag.Diameter, 1e-12)
+	assert.InDelta(t, 4.0/3, diag.Centroid.Terms[0], 1e-12)
+	assert.InDelta(t, 1, diag.Centroid.Terms[1], 1e-12)
+	assert.Equal(t, before, s)
+
+	msg := panicMessage(func() { NewSimplex(2).Diagnostics() })
+	assert.Equal(t, `Diagnostics: Simplex: got 0 points, expected 3 for 2 dimensions`, msg)
+}
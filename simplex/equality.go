@@ -0,0 +1,128 @@
+package simplex
+
+import (
+	"fmt"
+	"math"
+)
+
+// LinearEquality is the affine subspace of points x satisfying
+// the linear equations A x = B, with one row of A and one entry
+// of B per equation
+type LinearEquality struct {
+	A [][]float64
+	B []float64
+}
+
+// validate checks that e holds independent equations over dims
+// dimensions, without which there is no unique projection
+func (e *LinearEquality) validate(dims int) error {
+	if len(e.A) != len(e.B) {
+		return fmt.Errorf(`Options: equality has %d rows in A but %d values in B`,
+			len(e.A), len(e.B))
+	}
+	for i, row := range e.A {
+		if len(row) != dims {
+			return fmt.Errorf(`Options: equality row %d has %d coefficients, expected %d`,
+				i, len(row), dims)
+		}
+	}
+	if determinant(e.gram()) == 0 {
+		return fmt.Errorf(`Options: equality rows are linearly dependent`)
+	}
+	return nil
+}
+
+// gram returns A Aᵀ
+func (e *LinearEquality) gram() [][]float64 {
+	g := make([][]float64, len(e.A))
+	for i := range g {
+		g[i] = make([]float64, len(e.A))
+		for j := range g[i] {
+			g[i][j] = dot(e.A[i], e.A[j])
+		}
+	}
+	return g
+}
+
+// Project moves p in place to the nearest point satisfying the
+// equations, x - Aᵀ(A Aᵀ)⁻¹(A x - B), which is the least squares
+// correction of p. The rows of A must be linearly independent.
+func (e *LinearEquality) Project(p *Point) {
+	if len(e.A) == 0 {
+		return
+	}
+	residual := make([]float64, len(e.A))
+	for i, row := range e.A {
+		residual[i] = dot(row, p.Terms) - e.B[i]
+	}
+	y := solve(e.gram(), residual)
+	for i, row := range e.A {
+		for d, a := range row {
+			p.Terms[d] -= a * y[i]
+		}
+	}
+}
+
+// scaled returns the equations in the coordinates of
+// Options.Scale, where x = scale ∘ u so A x = B becomes
+// (A diag(scale)) u = B
+func (e *LinearEquality) scaled(scale []float64) *LinearEquality {
+	ret := &LinearEquality{A: make([][]float64, len(e.A)), B: e.B}
+	for i, row := range e.A {
+		ret.A[i] = make([]float64, len(row))
+		for d, a := range row {
+			ret.A[i][d] = a * scale[d]
+		}
+	}
+	return ret
+}
+
+// feasible moves p in place onto opts.Equality, if it is set,
+// and then back inside opts.Bounds
+func feasible(p *Point, opts *Options) {
+	if opts.Equality != nil {
+		opts.Equality.Project(p)
+	}
+	constrain(p, opts.Bounds, opts.BoundaryMode)
+}
+
+// solve returns x with m x = rhs by Gaussian elimination with
+// partial pivoting. m must be square and nonsingular, and both
+// m and rhs are overwritten.
+func solve(m [][]float64, rhs []float64) []float64 {
+	n := len(m)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		m[pivot], m[col] = m[col], m[pivot]
+		rhs[pivot], rhs[col] = rhs[col], rhs[pivot]
+		for row := col + 1; row < n; row++ {
+			factor := m[row][col] / m[col][col]
+			for c := col; c < n; c++ {
+				m[row][c] -= factor * m[col][c]
+			}
+			rhs[row] -= factor * rhs[col]
+		}
+	}
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		sum := rhs[row]
+		for c := row + 1; c < n; c++ {
+			sum -= m[row][c] * x[c]
+		}
+		x[row] = sum / m[row][row]
+	}
+	return x
+}
+
+func dot(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
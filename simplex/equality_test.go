@@ -0,0 +1,62 @@
+package simplex
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+func TestLinearEqualityProject(t *testing.T) {
+	// The plane x + y + z = 1
+	e := &LinearEquality{A: [][]float64{{1, 1, 1}}, B: []float64{1}}
+	p := &Point{Dims: 3, Terms: []float64{1, 1, 1}}
+	e.Project(p)
+	assert.True(t, p.ApproxEquals(&Point{Dims: 3, Terms: []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}}, 1e-12))
+
+	// The line x = 2, y = z
+	e = &LinearEquality{A: [][]float64{{1, 0, 0}, {0, 1, -1}}, B: []float64{2, 0}}
+	p = &Point{Dims: 3, Terms: []float64{0, 1, 3}}
+	e.Project(p)
+	assert.True(t, p.ApproxEquals(&Point{Dims: 3, Terms: []float64{2, 2, 2}}, 1e-12))
+}
+
+func TestOptimizeEquality(t *testing.T) {
+	// Weights summing to 1 as close as possible to target,
+	// which is the projection of target onto the constraint
+	target := []float64{1, 0.5, 0}
+	sumsToOne := func(p *Point) bool {
+		return math.Abs(p.Terms[0]+p.Terms[1]+p.Terms[2]-1) < 1e-9
+	}
+	eval := func(p *Point) float64 {
+		assert.True(t, sumsToOne(p))
+		sum := 0.0
+		for d, v := range p.Terms {
+			sum += math.Pow(v-target[d], 2)
+		}
+		return sum
+	}
+	opts := seededOptions(1)
+	opts.Equality = &LinearEquality{A: [][]float64{{1, 1, 1}}, B: []float64{1}}
+	opts.FTol = 1e-14
+	opts.MaxIters = 2000
+	result, err := OptimizeWithOptions(eval, 3, opts)
+	assert.NoError(t, err)
+	assert.True(t, sumsToOne(result.Best))
+	expected := &Point{Dims: 3, Terms: []float64{1 - 0.5/3, 0.5 - 0.5/3, -0.5 / 3}}
+	assert.True(t, result.Best.ApproxEquals(expected, 1e-4))
+}
+
+func TestOptimizeEqualityInvalid(t *testing.T) {
+	eval := func(p *Point) float64 { return 0 }
+	for _, e := range []*LinearEquality{
+		{A: [][]float64{{1, 1}}, B: []float64{1, 2}},
+		{A: [][]float64{{1, 1, 1}}, B: []float64{1}},
+		{A: [][]float64{{1, 1}, {2, 2}}, B: []float64{1, 2}},
+	} {
+		opts := DefaultOptions()
+		opts.Equality = e
+		_, err := OptimizeWithOptions(eval, 2, opts)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,78 @@
+package simplex
+
+import (
+	"math"
+	"sync"
+)
+
+// FallibleObjective adapts an objective which can fail, such as
+// a simulation which may crash, to the Objective interface. A
+// point at which the objective returns an error is given a
+// value of +Inf, so that it is treated as worse than any
+// feasible point, and the failure is counted by Failures as
+// well as in Result.BadEvaluations. It is safe for
+// concurrent use if the wrapped function is.
+type FallibleObjective struct {
+	f              func(p *Point) (float64, error)
+	maxConsecutive int
+
+	mu          sync.Mutex
+	failures    int
+	consecutive int
+	err         error
+}
+
+// Fallible returns a FallibleObjective evaluating f. If
+// maxConsecutive is positive, OptimizeObjective and
+// OptimizeObjectiveFrom stop the optimization with
+// StopFailures, between iterations, once that many evaluations
+// in a row have failed.
+func Fallible(f func(p *Point) (float64, error), maxConsecutive int) *FallibleObjective {
+	return &FallibleObjective{f: f, maxConsecutive: maxConsecutive}
+}
+
+// Evaluate returns the value of the objective at p, or +Inf if
+// it returns an error
+func (o *FallibleObjective) Evaluate(p *Point) float64 {
+	value, err := o.f(p)
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if err != nil {
+		o.failures++
+		o.consecutive++
+		o.err = err
+		return math.Inf(1)
+	}
+	o.consecutive = 0
+	return value
+}
+
+// Failures returns the number of evaluations which returned an
+// error
+func (o *FallibleObjective) Failures() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.failures
+}
+
+// Err returns the error of the latest failed evaluation, if any
+func (o *FallibleObjective) Err() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.err
+}
+
+// aborted reports whether the latest maxConsecutive evaluations
+// have all failed
+func (o *FallibleObjective) aborted() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.maxConsecutive > 0 && o.consecutive >= o.maxConsecutive
+}
+
+// aborter is implemented by objectives which can ask for the
+// optimization to stop
+type aborter interface {
+	aborted() bool
+}
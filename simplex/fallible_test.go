@@ -0,0 +1,69 @@
+package simplex
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+var errCrashed = errors.New(`simulation crashed`)
+
+func TestFallible(t *testing.T) {
+	// The simulation crashes for x > 1, on the far side of the
+	// minimum at (0.5, -1) from the start
+	obj := Fallible(func(p *Point) (float64, error) {
+		if p.Terms[0] > 1 {
+			return 0, errCrashed
+		}
+		return math.Pow(p.Terms[0]-0.5, 2) + math.Pow(p.Terms[1]+1, 2), nil
+	}, 10)
+	opts := DefaultOptions()
+	opts.FTol = 1e-12
+	opts.MaxIters = 500
+	result, err := OptimizeObjectiveFrom(obj, SimplexAround(&Point{Dims: 2, Terms: []float64{-3, 2}}, 1), opts)
+	assert.NoError(t, err)
+	assert.True(t, result.Converged)
+	assert.InDelta(t, 0.5, result.Best.Terms[0], 1e-4)
+	assert.InDelta(t, -1, result.Best.Terms[1], 1e-4)
+	assert.True(t, obj.Failures() > 0)
+	assert.Equal(t, obj.Failures(), result.BadEvaluations)
+	assert.Equal(t, errCrashed, obj.Err())
+}
+
+func TestFallibleAbort(t *testing.T) {
+	calls := 0
+	obj := Fallible(func(p *Point) (float64, error) {
+		calls++
+		if calls > 6 {
+			return 0, errCrashed
+		}
+		return p.Dot(p), nil
+	}, 4)
+	opts := seededOptions(1)
+	opts.FTol = 0
+	opts.MaxIters = 100
+	result, err := OptimizeObjective(obj, 2, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, StopFailures, result.StopReason)
+	assert.False(t, result.Converged)
+	assert.True(t, result.Iterations < 100)
+	assert.True(t, obj.Failures() >= 4)
+	// The best point was found before the failures began
+	assert.False(t, math.IsInf(result.BestValue, 0))
+
+	// Without a limit the failures alone never stop the
+	// optimization
+	calls = 0
+	obj = Fallible(func(p *Point) (float64, error) {
+		calls++
+		if calls > 6 {
+			return 0, errCrashed
+		}
+		return p.Dot(p), nil
+	}, 0)
+	result, err = OptimizeObjective(obj, 2, opts)
+	assert.NoError(t, err)
+	assert.True(t, result.StopReason != StopFailures)
+}
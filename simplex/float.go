@@ -0,0 +1,53 @@
+package simplex
+
+import "fmt"
+
+// Steps of the initial simplex built by OptimizeFloat, as used
+// by MATLAB's fminsearch: each coordinate is offset by 5% of its
+// magnitude, or by 0.00025 where it is zero
+const (
+	floatRelativeStep = 0.05
+	floatZeroStep     = 0.00025
+)
+
+// OptimizeFloat is like OptimizeFrom but works with plain
+// slices: eval is given the coordinates of each point, which it
+// must neither modify nor keep, and the coordinates of the best
+// point are returned with its value. The initial simplex is
+// built around start as by SimplexAroundRelative with the steps
+// of MATLAB's fminsearch, or by offsetting each dimension d by
+// opts.InitialStep times opts.Scale[d] if InitialStep is
+// positive. start is not modified.
+func OptimizeFloat(eval func(x []float64) float64, start []float64, opts *Options) ([]float64, float64, error) {
+	if len(start) < 1 {
+		return nil, 0, fmt.Errorf(`need at least 1 dimension, got %d`, len(start))
+	}
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, 0, err
+	}
+	if err := validateScale(opts.Scale, len(start)); err != nil {
+		return nil, 0, err
+	}
+	base := &Point{Dims: len(start), Terms: append([]float64(nil), start...)}
+	var initial []*Point
+	if opts.InitialStep > 0 {
+		steps := make([]float64, len(start))
+		for d := range steps {
+			steps[d] = opts.InitialStep
+			if opts.Scale != nil {
+				steps[d] *= opts.Scale[d]
+			}
+		}
+		initial = SimplexAroundSteps(base, steps)
+	} else {
+		initial = SimplexAroundRelative(base, floatRelativeStep, floatZeroStep)
+	}
+	result, err := OptimizeFrom(func(p *Point) float64 { return eval(p.Terms) }, initial, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	return result.Best.Terms, result.BestValue, nil
+}
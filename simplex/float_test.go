@@ -0,0 +1,40 @@
+package simplex
+
+import (
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+func TestOptimizeFloat(t *testing.T) {
+	quadratic := func(x []float64) float64 {
+		return (x[0]-1)*(x[0]-1) + 2*(x[1]+3)*(x[1]+3) + x[2]*x[2]
+	}
+	start := []float64{4, 0, 2}
+	opts := DefaultOptions()
+	opts.FTol = 1e-14
+	opts.MaxIters = 2000
+	best, value, err := OptimizeFloat(quadratic, start, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(best))
+	assert.InDelta(t, 1, best[0], 1e-4)
+	assert.InDelta(t, -3, best[1], 1e-4)
+	assert.InDelta(t, 0, best[2], 1e-4)
+	assert.InDelta(t, 0, value, 1e-8)
+	assert.Equal(t, []float64{4, 0, 2}, start)
+
+	// The initial step can be set, and nil options are allowed
+	opts.InitialStep = 1
+	stepped, _, err := OptimizeFloat(quadratic, start, opts)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1, stepped[0], 1e-4)
+	_, _, err = OptimizeFloat(quadratic, start, nil)
+	assert.NoError(t, err)
+
+	_, _, err = OptimizeFloat(quadratic, nil, nil)
+	assert.EqualError(t, err, `need at least 1 dimension, got 0`)
+	opts = DefaultOptions()
+	opts.Scale = []float64{1}
+	_, _, err = OptimizeFloat(quadratic, start, opts)
+	assert.EqualError(t, err, `Options: got scale for 1 dimensions, expected 3`)
+}
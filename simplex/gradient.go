@@ -0,0 +1,50 @@
+package simplex
+
+import (
+	"fmt"
+	"math"
+)
+
+// EstimateGradient returns the gradient of eval at p estimated
+// by central differences: the slope along dimension d is taken
+// between p moved by -h and by +h along d. It costs two
+// evaluations per dimension and panics unless h is positive. p
+// is not modified.
+func EstimateGradient(eval func(p *Point) float64, p *Point, h float64) *Point {
+	if !(h > 0) {
+		panic(fmt.Sprintf(`EstimateGradient: step must be positive, got %v`, h))
+	}
+	return estimateGradient(eval, p, math.NaN(), h, nil)
+}
+
+// estimateGradient returns the gradient of eval at p, whose
+// value is value, by differences with step h. Where bounds are
+// set, a step which would leave them is cut short at the bound,
+// so the difference becomes one-sided at a bound and the slope
+// along a dimension of zero width is zero. Unless it is NaN,
+// value is used in place of evaluating p again.
+func estimateGradient(eval func(p *Point) float64, p *Point, value, h float64, bounds [][2]float64) *Point {
+	gradient := NewPoint(p.Dims)
+	probe := p.Clone()
+	at := func(d int, v float64) float64 {
+		if v == p.Terms[d] && !math.IsNaN(value) {
+			return value
+		}
+		probe.Terms[d] = v
+		result := eval(probe)
+		probe.Terms[d] = p.Terms[d]
+		return result
+	}
+	for d, v := range p.Terms {
+		lo, hi := v-h, v+h
+		if bounds != nil {
+			lo = math.Max(lo, bounds[d][0])
+			hi = math.Min(hi, bounds[d][1])
+		}
+		if hi <= lo {
+			continue
+		}
+		gradient.Terms[d] = (at(d, hi) - at(d, lo)) / (hi - lo)
+	}
+	return gradient
+}
@@ -0,0 +1,104 @@
+package simplex
+
+import (
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+// quadratic has its minimum at (1, -2) and gradient
+// quadraticGradient
+func quadratic(p *Point) float64 {
+	x, y := p.Terms[0], p.Terms[1]
+	return (x-1)*(x-1) + 3*(y+2)*(y+2) + (x-1)*(y+2)
+}
+
+func quadraticGradient(p *Point) []float64 {
+	x, y := p.Terms[0], p.Terms[1]
+	return []float64{2*(x-1) + (y + 2), 6*(y+2) + (x - 1)}
+}
+
+func TestEstimateGradient(t *testing.T) {
+	for _, terms := range [][]float64{{0, 0}, {1, -2}, {-3.5, 7}} {
+		p := &Point{Dims: 2, Terms: terms}
+		evals := 0
+		eval := func(p *Point) float64 {
+			evals++
+			return quadratic(p)
+		}
+		gradient := EstimateGradient(eval, p, 1e-3)
+		assert.Equal(t, 4, evals)
+		for d, v := range quadraticGradient(p) {
+			assert.InDelta(t, v, gradient.Terms[d], 1e-6)
+		}
+		// p is left as it was
+		assert.Equal(t, terms, p.Terms)
+	}
+
+	msg := panicMessage(func() { EstimateGradient(quadratic, NewPoint(2), 0) })
+	assert.Equal(t, `EstimateGradient: step must be positive, got 0`, msg)
+}
+
+func TestEstimateGradientBounds(t *testing.T) {
+	p := &Point{Dims: 2, Terms: []float64{0, 0}}
+	want := quadraticGradient(p)
+	// A step toward x < 0 leaves the bounds, so the slope along
+	// x is a forward difference, and y has no room at all
+	bounds := [][2]float64{{0, 10}, {0, 0}}
+	gradient := estimateGradient(quadratic, p, quadratic(p), 1e-4, bounds)
+	assert.InDelta(t, want[0], gradient.Terms[0], 1e-3)
+	assert.Equal(t, 0.0, gradient.Terms[1])
+
+	// A bound closer than the step shortens it on one side only
+	bounds = [][2]float64{{-1e-5, 10}, {-10, 10}}
+	gradient = estimateGradient(quadratic, p, quadratic(p), 1e-3, bounds)
+	for d, v := range want {
+		assert.InDelta(t, v, gradient.Terms[d], 1e-3)
+	}
+}
+
+func TestOptimizeGradient(t *testing.T) {
+	opts := seededOptions(1)
+	opts.FTol = 1e-14
+	opts.MaxIters = 1000
+	result, err := OptimizeWithOptions(quadratic, 2, opts)
+	assert.NoError(t, err)
+	assert.Nil(t, result.Gradient)
+
+	opts.GradientStep = 1e-4
+	result, err = OptimizeWithOptions(quadratic, 2, opts)
+	assert.NoError(t, err)
+	for d, v := range quadraticGradient(result.Best) {
+		assert.InDelta(t, v, result.Gradient.Terms[d], 1e-6)
+		assert.InDelta(t, 0, result.Gradient.Terms[d], 1e-4)
+	}
+
+	// The gradient is in the original coordinates and the sign
+	// of the objective
+	at := &Point{Dims: 2, Terms: []float64{3, 1}}
+	opts = DefaultOptions()
+	opts.MaxIters = 0
+	opts.Maximize = true
+	opts.Scale = []float64{10, 0.1}
+	opts.GradientStep = 1e-4
+	negated := func(p *Point) float64 { return -quadratic(p) }
+	result, err = OptimizeFrom(negated, SimplexAround(at, 0.5), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, at, result.Best)
+	for d, v := range quadraticGradient(at) {
+		assert.InDelta(t, -v, result.Gradient.Terms[d], 1e-3)
+	}
+
+	// No evaluations are left for the gradient
+	opts = DefaultOptions()
+	opts.MaxEvaluations = 10
+	opts.GradientStep = 1e-4
+	result, err = OptimizeFrom(quadratic, SimplexAround(at, 0.5), opts)
+	assert.NoError(t, err)
+	assert.Nil(t, result.Gradient)
+	assert.True(t, result.Evaluations <= 10)
+
+	opts = DefaultOptions()
+	opts.GradientStep = -1
+	assert.EqualError(t, opts.Validate(), `Options: GradientStep must not be negative, got -1`)
+}
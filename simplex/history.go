@@ -0,0 +1,65 @@
+package simplex
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// historyWriter writes the progress of an optimization as CSV,
+// one row per iteration, for loading into analysis tools
+type historyWriter struct {
+	w        *csv.Writer
+	dims     int
+	points   bool
+	maximize bool
+	header   bool
+}
+
+func newHistoryWriter(w io.Writer, dims int, opts *Options) *historyWriter {
+	return &historyWriter{
+		w:        csv.NewWriter(w),
+		dims:     dims,
+		points:   opts.HistoryPoints,
+		maximize: opts.Maximize,
+	}
+}
+
+// writeRow writes the row for the given iteration, preceded by
+// the header row on the first call. Costs are reported in the
+// sign of the objective.
+func (h *historyWriter) writeRow(iter int, s *Simplex) error {
+	if !h.header {
+		header := []string{`iteration`, `best`, `worst`, `stddev`}
+		if h.points {
+			for d := 0; d < h.dims; d++ {
+				header = append(header, `x`+strconv.Itoa(d))
+			}
+		}
+		if err := h.w.Write(header); err != nil {
+			return err
+		}
+		h.header = true
+	}
+	best, worst := s.Evaluations[0], s.Evaluations[len(s.Evaluations)-1]
+	if h.maximize {
+		best, worst = -best, -worst
+	}
+	row := []string{strconv.Itoa(iter), formatFloat(best),
+		formatFloat(worst), formatFloat(s.StdDev())}
+	if h.points {
+		for _, v := range s.Points[0].Terms {
+			row = append(row, formatFloat(v))
+		}
+	}
+	return h.w.Write(row)
+}
+
+func (h *historyWriter) flush() error {
+	h.w.Flush()
+	return h.w.Error()
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
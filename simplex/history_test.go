@@ -0,0 +1,67 @@
+package simplex
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+func TestOptimizeHistoryCSV(t *testing.T) {
+	eval := func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-3, 2) + math.Pow(p.Terms[1]-4, 2)
+	}
+	initial := []*Point{
+		{Dims: 2, Terms: []float64{0, 0}},
+		{Dims: 2, Terms: []float64{1, 0}},
+		{Dims: 2, Terms: []float64{0, 1}},
+	}
+	var history bytes.Buffer
+	opts := DefaultOptions()
+	opts.FTol = 0
+	opts.MaxIters = 3
+	opts.HistoryWriter = &history
+	opts.HistoryPoints = true
+	_, err := OptimizeFrom(eval, initial, opts)
+	assert.NoError(t, err)
+
+	rows, err := csv.NewReader(&history).ReadAll()
+	assert.NoError(t, err)
+	// The header followed by the initial simplex and one row
+	// per iteration
+	assert.Equal(t, 5, len(rows))
+	assert.Equal(t, []string{`iteration`, `best`, `worst`, `stddev`, `x0`, `x1`}, rows[0])
+	// The initial simplex sorted by cost: (0, 1), (1, 0), (0, 0)
+	assert.Equal(t, []string{`0`, `18`, `25`, `3.605551275463989`, `0`, `1`}, rows[1])
+	for i, row := range rows[1:] {
+		assert.Equal(t, strconv.Itoa(i), row[0])
+		assert.Equal(t, 6, len(row))
+	}
+}
+
+func TestOptimizeHistoryMaximize(t *testing.T) {
+	eval := func(p *Point) float64 {
+		return -math.Pow(p.Terms[0], 2)
+	}
+	initial := []*Point{
+		{Dims: 1, Terms: []float64{1}},
+		{Dims: 1, Terms: []float64{2}},
+	}
+	var history bytes.Buffer
+	opts := DefaultOptions()
+	opts.Maximize = true
+	opts.MaxIters = 0
+	opts.HistoryWriter = &history
+	_, err := OptimizeFrom(eval, initial, opts)
+	assert.NoError(t, err)
+
+	rows, err := csv.NewReader(&history).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{`iteration`, `best`, `worst`, `stddev`},
+		{`0`, `-1`, `-4`, `2.1213203435596424`},
+	}, rows)
+}
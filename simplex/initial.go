@@ -0,0 +1,46 @@
+package simplex
+
+import "math"
+
+// SimplexAround returns the dims+1 vertices of a simplex built
+// around base: the first vertex is a copy of base and vertex i
+// adds step to dimension i-1 of base
+func SimplexAround(base *Point, step float64) []*Point {
+	steps := make([]float64, base.Dims)
+	for d := range steps {
+		steps[d] = step
+	}
+	return SimplexAroundSteps(base, steps)
+}
+
+// SimplexAroundSteps is like SimplexAround but offsets each
+// dimension d by its own steps[d]
+func SimplexAroundSteps(base *Point, steps []float64) []*Point {
+	if len(steps) != base.Dims {
+		panic(`SimplexAroundSteps: need one step per dimension`)
+	}
+	points := make([]*Point, base.Dims+1)
+	points[0] = base.Clone()
+	for d, step := range steps {
+		p := base.Clone()
+		p.Terms[d] += step
+		points[d+1] = p
+	}
+	return points
+}
+
+// SimplexAroundRelative is like SimplexAround but offsets each
+// dimension by the fraction rel of its magnitude in base. A
+// relative offset collapses to zero for dimensions where base
+// is zero, so those are offset by the absolute step abs
+// instead.
+func SimplexAroundRelative(base *Point, rel, abs float64) []*Point {
+	steps := make([]float64, base.Dims)
+	for d, v := range base.Terms {
+		steps[d] = rel * math.Abs(v)
+		if steps[d] == 0 {
+			steps[d] = abs
+		}
+	}
+	return SimplexAroundSteps(base, steps)
+}
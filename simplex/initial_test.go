@@ -0,0 +1,45 @@
+package simplex
+
+import (
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+func TestSimplexAround(t *testing.T) {
+	base := &Point{Dims: 3, Terms: []float64{1, 2, 3}}
+	expected := []*Point{
+		{Dims: 3, Terms: []float64{1, 2, 3}},
+		{Dims: 3, Terms: []float64{1.5, 2, 3}},
+		{Dims: 3, Terms: []float64{1, 2.5, 3}},
+		{Dims: 3, Terms: []float64{1, 2, 3.5}},
+	}
+	points := SimplexAround(base, 0.5)
+	assert.Equal(t, expected, points)
+
+	// The base point is copied rather than shared
+	points[0].Terms[0] = 10
+	assert.Equal(t, []float64{1, 2, 3}, base.Terms)
+}
+
+func TestSimplexAroundSteps(t *testing.T) {
+	base := &Point{Dims: 2, Terms: []float64{0, 0}}
+	expected := []*Point{
+		{Dims: 2, Terms: []float64{0, 0}},
+		{Dims: 2, Terms: []float64{1, 0}},
+		{Dims: 2, Terms: []float64{0, -2}},
+	}
+	assert.Equal(t, expected, SimplexAroundSteps(base, []float64{1, -2}))
+	assert.Panics(t, func() { SimplexAroundSteps(base, []float64{1}) })
+}
+
+func TestSimplexAroundRelative(t *testing.T) {
+	// The zero dimension falls back to the absolute step
+	base := &Point{Dims: 2, Terms: []float64{-4, 0}}
+	expected := []*Point{
+		{Dims: 2, Terms: []float64{-4, 0}},
+		{Dims: 2, Terms: []float64{-3, 0}},
+		{Dims: 2, Terms: []float64{-4, 0.1}},
+	}
+	assert.Equal(t, expected, SimplexAroundRelative(base, 0.25, 0.1))
+}
@@ -0,0 +1,32 @@
+package simplex
+
+import (
+	"fmt"
+	"math"
+)
+
+// validateIntegerDims checks that every entry of integerDims is
+// one of dims dimensions
+func validateIntegerDims(integerDims []int, dims int) error {
+	for _, d := range integerDims {
+		if d < 0 || d >= dims {
+			return fmt.Errorf(`Options: IntegerDims entry %d out of range for %d dimensions`,
+				d, dims)
+		}
+	}
+	return nil
+}
+
+// roundDims returns a copy of p with the terms listed in
+// integerDims rounded to the nearest integer, or p itself if
+// integerDims is empty
+func roundDims(p *Point, integerDims []int) *Point {
+	if len(integerDims) == 0 {
+		return p
+	}
+	ret := p.Clone()
+	for _, d := range integerDims {
+		ret.Terms[d] = math.Round(ret.Terms[d])
+	}
+	return ret
+}
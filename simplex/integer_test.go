@@ -0,0 +1,61 @@
+package simplex
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+func TestRoundDims(t *testing.T) {
+	p := &Point{Dims: 3, Terms: []float64{1.4, 2.6, -0.5}}
+	assert.Equal(t, []float64{1.4, 3, -1}, roundDims(p, []int{1, 2}).Terms)
+	assert.Equal(t, []float64{1.4, 2.6, -0.5}, p.Terms)
+	assert.True(t, roundDims(p, nil) == p)
+}
+
+func TestOptimizeIntegerDims(t *testing.T) {
+	// The continuous minimum is at (2.4, -1.7), so the best
+	// point with an integer x is (2, -1.7) and the best integer
+	// point is (2, -2)
+	eval := func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-2.4, 2) + math.Pow(p.Terms[1]+1.7, 2)
+	}
+	for _, tc := range []struct {
+		integerDims []int
+		expected    []float64
+	}{
+		{[]int{0}, []float64{2, -1.7}},
+		{[]int{0, 1}, []float64{2, -2}},
+	} {
+		opts := seededOptions(1)
+		opts.IntegerDims = tc.integerDims
+		opts.FTol = 0
+		opts.XTol = 1e-9
+		opts.MaxIters = 1000
+		result, err := OptimizeWithOptions(func(p *Point) float64 {
+			for _, d := range tc.integerDims {
+				if p.Terms[d] != math.Trunc(p.Terms[d]) {
+					t.Fatalf(`evaluated fractional coordinate %v`, p.Terms)
+				}
+			}
+			return eval(p)
+		}, 2, opts)
+		assert.NoError(t, err)
+		for d, v := range tc.expected {
+			assert.InDelta(t, v, result.Best.Terms[d], 1e-4)
+		}
+		// Integer coordinates land exactly
+		for _, d := range tc.integerDims {
+			assert.Equal(t, tc.expected[d], result.Best.Terms[d])
+		}
+		assert.Equal(t, eval(result.Best), result.BestValue)
+	}
+}
+
+func TestOptimizeIntegerDimsOutOfRange(t *testing.T) {
+	opts := DefaultOptions()
+	opts.IntegerDims = []int{2}
+	_, err := OptimizeWithOptions(func(p *Point) float64 { return 0 }, 2, opts)
+	assert.EqualError(t, err, `Options: IntegerDims entry 2 out of range for 2 dimensions`)
+}
@@ -0,0 +1,261 @@
+package simplex
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// jsonFloat encodes a float64 as a JSON number in full
+// precision, or as one of the strings "NaN", "+Inf" and "-Inf",
+// which JSON numbers cannot represent
+type jsonFloat float64
+
+func (f jsonFloat) MarshalJSON() ([]byte, error) {
+	v := float64(f)
+	switch {
+	case math.IsNaN(v):
+		return []byte(`"NaN"`), nil
+	case math.IsInf(v, 1):
+		return []byte(`"+Inf"`), nil
+	case math.IsInf(v, -1):
+		return []byte(`"-Inf"`), nil
+	}
+	return json.Marshal(v)
+}
+
+func (f *jsonFloat) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		var v float64
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		*f = jsonFloat(v)
+		return nil
+	}
+	switch name {
+	case `NaN`:
+		*f = jsonFloat(math.NaN())
+	case `+Inf`:
+		*f = jsonFloat(math.Inf(1))
+	case `-Inf`:
+		*f = jsonFloat(math.Inf(-1))
+	default:
+		return fmt.Errorf(`invalid number %q`, name)
+	}
+	return nil
+}
+
+// toJSONFloats converts values to jsonFloats, keeping nil as nil
+func toJSONFloats(values []float64) []jsonFloat {
+	if values == nil {
+		return nil
+	}
+	ret := make([]jsonFloat, len(values))
+	for i, v := range values {
+		ret[i] = jsonFloat(v)
+	}
+	return ret
+}
+
+// fromJSONFloats is the inverse of toJSONFloats
+func fromJSONFloats(values []jsonFloat) []float64 {
+	if values == nil {
+		return nil
+	}
+	ret := make([]float64, len(values))
+	for i, v := range values {
+		ret[i] = float64(v)
+	}
+	return ret
+}
+
+type jsonPoint struct {
+	Dims  int         `json:"dims"`
+	Terms []jsonFloat `json:"terms"`
+}
+
+// MarshalJSON encodes p as an object holding its dimension
+// and terms. Terms which are NaN or infinite are written as
+// strings, as jsonFloat does.
+func (p *Point) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonPoint{Dims: p.Dims, Terms: toJSONFloats(p.Terms)})
+}
+
+// UnmarshalJSON decodes a point written by MarshalJSON
+func (p *Point) UnmarshalJSON(data []byte) error {
+	var jp jsonPoint
+	if err := json.Unmarshal(data, &jp); err != nil {
+		return err
+	}
+	if len(jp.Terms) != jp.Dims {
+		return fmt.Errorf(`Point: got %d terms for %d dimensions`, len(jp.Terms), jp.Dims)
+	}
+	p.Dims = jp.Dims
+	p.Terms = fromJSONFloats(jp.Terms)
+	return nil
+}
+
+type jsonSimplex struct {
+	Dimension   int         `json:"dimension"`
+	Points      []*Point    `json:"points"`
+	Evaluations []jsonFloat `json:"evaluations"`
+	Iterations  int         `json:"iterations,omitempty"`
+}
+
+// MarshalJSON encodes s as an object holding its dimension,
+// points and their evaluations. Evaluations which are not
+// finite are encoded as the strings "NaN", "+Inf" or "-Inf".
+func (s *Simplex) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonSimplex{
+		Dimension:   s.Dimension,
+		Points:      s.Points,
+		Evaluations: toJSONFloats(s.Evaluations),
+		Iterations:  s.Iterations,
+	})
+}
+
+// UnmarshalJSON decodes a simplex written by MarshalJSON. The
+// points are sorted by their evaluations in case the input
+// was not.
+func (s *Simplex) UnmarshalJSON(data []byte) error {
+	var js jsonSimplex
+	if err := json.Unmarshal(data, &js); err != nil {
+		return err
+	}
+	if len(js.Points) != len(js.Evaluations) {
+		return fmt.Errorf(`Simplex: got %d points but %d evaluations`,
+			len(js.Points), len(js.Evaluations))
+	}
+	if len(js.Points) > js.Dimension+1 {
+		return fmt.Errorf(`Simplex: got %d points for %d dimensions`,
+			len(js.Points), js.Dimension)
+	}
+	evaluations := fromJSONFloats(js.Evaluations)
+	if js.Points == nil {
+		js.Points = make([]*Point, 0)
+		evaluations = make([]float64, 0)
+	}
+	sort.Stable(byEvaluation{js.Points, evaluations})
+
+	s.Dimension = js.Dimension
+	s.Points = js.Points
+	s.Evaluations = evaluations
+	s.Iterations = js.Iterations
+	s.numInitialized = len(js.Points)
+	return nil
+}
+
+// MarshalText encodes o as its name
+func (o Operation) MarshalText() ([]byte, error) {
+	if o < OpReflect || o > OpRepair {
+		return nil, fmt.Errorf(`unknown %v`, o)
+	}
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText decodes an operation written by MarshalText
+func (o *Operation) UnmarshalText(text []byte) error {
+	for op := OpReflect; op <= OpRepair; op++ {
+		if op.String() == string(text) {
+			*o = op
+			return nil
+		}
+	}
+	return fmt.Errorf(`unknown operation %q`, text)
+}
+
+type jsonCoefficients struct {
+	Reflect  float64 `json:"reflect"`
+	Expand   float64 `json:"expand"`
+	Contract float64 `json:"contract"`
+	Shrink   float64 `json:"shrink"`
+}
+
+type jsonResult struct {
+	Best           *Point           `json:"best"`
+	BestValue      jsonFloat        `json:"bestValue"`
+	FinalSimplex   *Simplex         `json:"finalSimplex"`
+	Coefficients   jsonCoefficients `json:"coefficients"`
+	Iterations     int              `json:"iterations"`
+	Evaluations    int              `json:"evaluations"`
+	Converged      bool             `json:"converged"`
+	StopReason     StopReason       `json:"stopReason"`
+	Operations     []Operation      `json:"operations,omitempty"`
+	Path           []*Point         `json:"path,omitempty"`
+	BestValues     []jsonFloat      `json:"bestValues,omitempty"`
+	WorstValues    []jsonFloat      `json:"worstValues,omitempty"`
+	CentroidValues []jsonFloat      `json:"centroidValues,omitempty"`
+	Gradient       []jsonFloat      `json:"gradient,omitempty"`
+	BadEvaluations int              `json:"badEvaluations,omitempty"`
+	CacheHits      int              `json:"cacheHits,omitempty"`
+	Repairs        int              `json:"repairs,omitempty"`
+	Restarts       int              `json:"restarts,omitempty"`
+	RestartValues  []jsonFloat      `json:"restartValues,omitempty"`
+}
+
+// MarshalJSON encodes every field of r, with values in full
+// precision and the operations by name, so that archived runs
+// can be decoded and compared
+func (r *Result) MarshalJSON() ([]byte, error) {
+	var gradient []jsonFloat
+	if r.Gradient != nil {
+		gradient = toJSONFloats(r.Gradient.Terms)
+	}
+	return json.Marshal(jsonResult{
+		Best:           r.Best,
+		BestValue:      jsonFloat(r.BestValue),
+		FinalSimplex:   r.FinalSimplex,
+		Coefficients:   jsonCoefficients(r.Coefficients),
+		Iterations:     r.Iterations,
+		Evaluations:    r.Evaluations,
+		Converged:      r.Converged,
+		StopReason:     r.StopReason,
+		Operations:     r.Operations,
+		Path:           r.Path,
+		BestValues:     toJSONFloats(r.BestValues),
+		WorstValues:    toJSONFloats(r.WorstValues),
+		CentroidValues: toJSONFloats(r.CentroidValues),
+		Gradient:       gradient,
+		BadEvaluations: r.BadEvaluations,
+		CacheHits:      r.CacheHits,
+		Repairs:        r.Repairs,
+		Restarts:       r.Restarts,
+		RestartValues:  toJSONFloats(r.RestartValues),
+	})
+}
+
+// UnmarshalJSON decodes a result written by MarshalJSON
+func (r *Result) UnmarshalJSON(data []byte) error {
+	var jr jsonResult
+	if err := json.Unmarshal(data, &jr); err != nil {
+		return err
+	}
+	*r = Result{
+		Best:           jr.Best,
+		BestValue:      float64(jr.BestValue),
+		FinalSimplex:   jr.FinalSimplex,
+		Coefficients:   Coefficients(jr.Coefficients),
+		Iterations:     jr.Iterations,
+		Evaluations:    jr.Evaluations,
+		Converged:      jr.Converged,
+		StopReason:     jr.StopReason,
+		Operations:     jr.Operations,
+		Path:           jr.Path,
+		BestValues:     fromJSONFloats(jr.BestValues),
+		WorstValues:    fromJSONFloats(jr.WorstValues),
+		CentroidValues: fromJSONFloats(jr.CentroidValues),
+		BadEvaluations: jr.BadEvaluations,
+		CacheHits:      jr.CacheHits,
+		Repairs:        jr.Repairs,
+		Restarts:       jr.Restarts,
+		RestartValues:  fromJSONFloats(jr.RestartValues),
+	}
+	if jr.Gradient != nil {
+		terms := fromJSONFloats(jr.Gradient)
+		r.Gradient = &Point{Dims: len(terms), Terms: terms}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package simplex
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+func TestSimplexJSONRoundTrip(t *testing.T) {
+	s := NewSimplex(2)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{0.1, -2}}, 1.5)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{1.0 / 3, 4}}, -7.25)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{5, 6e-9}}, 3)
+
+	data, err := json.Marshal(s)
+	assert.NoError(t, err)
+
+	decoded := &Simplex{}
+	assert.NoError(t, json.Unmarshal(data, decoded))
+	assert.Equal(t, s, decoded)
+}
+
+func TestPointJSONNonFinite(t *testing.T) {
+	p := &Point{Dims: 3, Terms: []float64{math.Inf(1), -2.5, math.Inf(-1)}}
+	data, err := json.Marshal(p)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"dims":3,"terms":["+Inf",-2.5,"-Inf"]}`, string(data))
+
+	decoded := &Point{}
+	assert.NoError(t, json.Unmarshal(data, decoded))
+	assert.Equal(t, p, decoded)
+
+	data, err = json.Marshal(&Point{Dims: 1, Terms: []float64{math.NaN()}})
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(data, decoded))
+	assert.True(t, math.IsNaN(decoded.Terms[0]))
+}
+
+func TestSimplexJSONSorts(t *testing.T) {
+	data := []byte(`{"dimension":1,"points":[` +
+		`{"dims":1,"terms":[2]},{"dims":1,"terms":[1]}],"evaluations":[20,10]}`)
+	s := &Simplex{}
+	assert.NoError(t, json.Unmarshal(data, s))
+	assert.Equal(t, []float64{10, 20}, s.Evaluations)
+	assert.Equal(t, []float64{1}, s.Points[0].Terms)
+	assert.Equal(t, []float64{2}, s.Points[1].Terms)
+}
+
+func TestSimplexJSONInvalid(t *testing.T) {
+	s := &Simplex{}
+	assert.Error(t, json.Unmarshal([]byte(`{"dimension":1,"points":[`+
+		`{"dims":1,"terms":[2]}],"evaluations":[20,10]}`), s))
+	assert.Error(t, json.Unmarshal([]byte(`{"dimension":1,"points":[`+
+		`{"dims":2,"terms":[2]}],"evaluations":[20]}`), s))
+}
+
+func TestOptimizeFromCheckpoint(t *testing.T) {
+	eval := func(p *Point) float64 {
+		x, y := p.Terms[0], p.Terms[1]
+		return math.Pow(1-x, 2) + 100*math.Pow(y-x*x, 2)
+	}
+	initial := func() []*Point {
+		return SimplexAround(&Point{Dims: 2, Terms: []float64{-1.2, 1}}, 0.5)
+	}
+	opts := DefaultOptions()
+	opts.FTol = 0
+	opts.MaxIters = 10
+	straight, err := OptimizeFrom(eval, initial(), opts)
+	assert.NoError(t, err)
+
+	opts.MaxIters = 5
+	first, err := OptimizeFrom(eval, initial(), opts)
+	assert.NoError(t, err)
+	data, err := json.Marshal(first.FinalSimplex)
+	assert.NoError(t, err)
+	checkpoint := &Simplex{}
+	assert.NoError(t, json.Unmarshal(data, checkpoint))
+	assert.Equal(t, 5, checkpoint.Iterations)
+
+	opts.MaxIters = 10
+	calls := 0
+	opts.Callback = func(iter int, s *Simplex) bool {
+		calls++
+		return true
+	}
+	resumed, err := OptimizeFromCheckpoint(eval, checkpoint, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, calls)
+	assert.Equal(t, 10, resumed.Iterations)
+	assert.Equal(t, straight.FinalSimplex.Evaluations, resumed.FinalSimplex.Evaluations)
+	assert.Equal(t, straight.FinalSimplex.Points, resumed.FinalSimplex.Points)
+	assert.Equal(t, straight.Best, resumed.Best)
+	// The checkpoint itself is left untouched
+	assert.Equal(t, 5, checkpoint.Iterations)
+}
+
+func TestOptimizeFromInvalidCheckpoint(t *testing.T) {
+	eval := func(p *Point) float64 { return 0 }
+	s := NewSimplex(2)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{0, 0}}, 0)
+	_, err := OptimizeFromCheckpoint(eval, s, nil)
+	assert.EqualError(t, err, `Simplex: got 1 points, expected 3 for 2 dimensions`)
+
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{1, 0}}, 0)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{0, 1}}, 0)
+	s.Iterations = -1
+	_, err = OptimizeFromCheckpoint(eval, s, nil)
+	assert.Error(t, err)
+}
+
+func TestResultJSONRoundTrip(t *testing.T) {
+	eval := func(p *Point) float64 {
+		if p.Terms[0] > 8 {
+			return math.NaN()
+		}
+		return math.Pow(p.Terms[0]-1.0/3, 2) + math.Pow(p.Terms[1]+0.1, 2)
+	}
+	opts := DefaultOptions()
+	opts.MaxIters = 40
+	opts.RecordPath = true
+	opts.RecordCentroid = true
+	opts.GradientStep = 1e-6
+	result, err := OptimizeFrom(eval, []*Point{
+		{Dims: 2, Terms: []float64{0, 0}},
+		{Dims: 2, Terms: []float64{1, 0}},
+		{Dims: 2, Terms: []float64{9, 1}},
+	}, opts)
+	assert.NoError(t, err)
+	assert.True(t, len(result.Operations) > 0)
+	assert.True(t, result.BadEvaluations > 0)
+	// Values which JSON numbers cannot hold
+	result.CentroidValues = append(result.CentroidValues, math.NaN())
+	result.RestartValues = []float64{math.Inf(1), -0.5}
+
+	data, err := json.Marshal(result)
+	assert.NoError(t, err)
+	decoded := &Result{}
+	assert.NoError(t, json.Unmarshal(data, decoded))
+	// NaN never equals itself, so compare the centroid values
+	// by their bits
+	assert.Equal(t, len(result.CentroidValues), len(decoded.CentroidValues))
+	for i, v := range result.CentroidValues {
+		assert.Equal(t, math.Float64bits(v), math.Float64bits(decoded.CentroidValues[i]))
+	}
+	result.CentroidValues, decoded.CentroidValues = nil, nil
+	assert.Equal(t, result, decoded)
+
+	// Operations are written by name
+	var raw map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &raw))
+	assert.Equal(t, result.Operations[0].String(), raw[`operations`].([]interface{})[0])
+}
+
+func TestJSONFloat(t *testing.T) {
+	for _, v := range []float64{0, -1.0 / 3, 1e-300, math.Inf(1), math.Inf(-1)} {
+		data, err := json.Marshal(jsonFloat(v))
+		assert.NoError(t, err)
+		var decoded jsonFloat
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, v, float64(decoded))
+	}
+	data, err := json.Marshal(jsonFloat(math.NaN()))
+	assert.NoError(t, err)
+	assert.Equal(t, `"NaN"`, string(data))
+
+	var f jsonFloat
+	assert.Error(t, json.Unmarshal([]byte(`"big"`), &f))
+	var op Operation
+	assert.Error(t, json.Unmarshal([]byte(`"twist"`), &op))
+}
@@ -0,0 +1,35 @@
+package simplex
+
+import "fmt"
+
+// LogLevel selects how much an optimization writes to
+// Options.Logger
+type LogLevel int
+
+const (
+	// LogInfo logs a summary of the result once the
+	// optimization stops
+	LogInfo LogLevel = iota
+	// LogDebug also logs the operation taken by every
+	// iteration
+	LogDebug
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogInfo:
+		return `info`
+	case LogDebug:
+		return `debug`
+	}
+	return fmt.Sprintf(`LogLevel(%d)`, int(l))
+}
+
+// logf writes a message to o.Logger if it is set and level is
+// enabled by o.LogLevel
+func (o *Options) logf(level LogLevel, format string, args ...interface{}) {
+	if o.Logger == nil || level > o.LogLevel {
+		return
+	}
+	o.Logger.Printf(format, args...)
+}
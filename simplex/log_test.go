@@ -0,0 +1,54 @@
+package simplex
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+func TestOptimizeLogger(t *testing.T) {
+	sphere := func(p *Point) float64 { return p.Dot(p) }
+	var buf bytes.Buffer
+	opts := seededOptions(1)
+	opts.Logger = log.New(&buf, ``, 0)
+	opts.LogLevel = LogDebug
+	opts.FTol = 0
+	opts.MaxIters = 100
+	result, err := OptimizeWithOptions(sphere, 2, opts)
+	assert.NoError(t, err)
+
+	out := buf.String()
+	for _, op := range []string{`reflect`, `expand`, `contract`} {
+		assert.True(t, strings.Contains(out, `: `+op+"\n"), op)
+	}
+	assert.True(t, strings.Contains(out, string(result.StopReason)))
+
+	// At the info level only the summary is logged
+	buf.Reset()
+	opts.LogLevel = LogInfo
+	_, err = OptimizeWithOptions(sphere, 2, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+
+	// On a flat objective every iteration shrinks, and once the
+	// shrinks stop making progress the simplex is restarted
+	buf.Reset()
+	opts = seededOptions(1)
+	opts.Logger = log.New(&buf, ``, 0)
+	opts.LogLevel = LogDebug
+	opts.FTol = 0
+	opts.MaxIters = 5000
+	opts.RestartOnNoProgress = true
+	result, err = OptimizeWithOptions(func(p *Point) float64 { return 1 }, 2, opts)
+	assert.NoError(t, err)
+	assert.True(t, result.Restarts > 0)
+	out = buf.String()
+	assert.True(t, strings.Contains(out, ": shrink\n"))
+	assert.True(t, strings.Contains(out, ": restart\n"))
+
+	opts.LogLevel = LogDebug + 1
+	assert.Error(t, opts.Validate())
+}
@@ -0,0 +1,145 @@
+package simplex
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+)
+
+// MultiStartMode selects how the runs of OptimizeMultiStart
+// relate to each other
+type MultiStartMode int
+
+const (
+	// MultiStartIndependent starts every run from its own
+	// random simplex
+	MultiStartIndependent MultiStartMode = iota
+	// MultiStartSeeded starts every run after the first from a
+	// random simplex one of whose vertices is replaced by the
+	// best point found by the earlier runs, so that each run
+	// explores from a new simplex while keeping the best point
+	// so far. The runs are performed one after another.
+	//
+	// Later runs usually need fewer evaluations and never end
+	// worse than the earlier ones, but they tend to return to
+	// the basin of the best point so far, so on multi-modal
+	// objectives they find the global optimum less often than
+	// independent runs.
+	MultiStartSeeded
+)
+
+func (m MultiStartMode) String() string {
+	switch m {
+	case MultiStartIndependent:
+		return `independent`
+	case MultiStartSeeded:
+		return `seeded`
+	}
+	return fmt.Sprintf(`MultiStartMode(%d)`, int(m))
+}
+
+// OptimizeMultiStart runs OptimizeWithOptions from restarts
+// random initial simplexes, related according to
+// opts.MultiStart, and returns the result of the best run, with
+// the best value of every run recorded in RestartValues.
+//
+// Each run draws its initial simplex from its own source
+// seeded from opts.Rand, so a seeded opts.Rand makes every
+// run reproducible. When opts.Parallel is set independent runs
+// are performed concurrently, in which case opts.Callback and
+// opts.TraceWriter must also be safe for concurrent use.
+func OptimizeMultiStart(eval func(p *Point) float64, dims, restarts int, opts *Options) (*Result, error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if restarts < 1 {
+		return nil, fmt.Errorf(`OptimizeMultiStart: need at least one restart, got %d`, restarts)
+	}
+
+	// Draw every seed up front so that the runs do not depend
+	// on the order in which they are scheduled
+	rng := optionsRand(opts)
+	seeds := make([]int64, restarts)
+	for i := range seeds {
+		seeds[i] = rng.Int63()
+	}
+
+	if opts.MultiStart == MultiStartSeeded {
+		return seededMultiStart(eval, dims, seeds, opts)
+	}
+	results := make([]*Result, restarts)
+	errs := make([]error, restarts)
+	run := func(i int) {
+		runOpts := *opts
+		runOpts.Rand = rand.New(rand.NewSource(seeds[i]))
+		results[i], errs[i] = OptimizeWithOptions(eval, dims, &runOpts)
+	}
+	if opts.Parallel {
+		var wg sync.WaitGroup
+		for i := range seeds {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				run(i)
+			}(i)
+		}
+		wg.Wait()
+	} else {
+		for i := range seeds {
+			run(i)
+		}
+	}
+
+	var best *Result
+	values := make([]float64, restarts)
+	for i, result := range results {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		values[i] = result.BestValue
+		if best == nil || better(result.BestValue, best.BestValue, opts.Maximize) {
+			best = result
+		}
+	}
+	best.RestartValues = values
+	return best, nil
+}
+
+// seededMultiStart performs the runs of OptimizeMultiStart in
+// MultiStartSeeded mode, one per seed, in order
+func seededMultiStart(eval func(p *Point) float64, dims int, seeds []int64, opts *Options) (*Result, error) {
+	var best *Result
+	values := make([]float64, len(seeds))
+	for i, seed := range seeds {
+		runOpts := *opts
+		runOpts.Rand = rand.New(rand.NewSource(seed))
+		if err := checkRandomDims(dims, &runOpts); err != nil {
+			return nil, err
+		}
+		points := randomPoints(runOpts.Rand, dims, &runOpts)
+		if best != nil {
+			points[0] = best.Best.Clone()
+		}
+		result, err := OptimizeFrom(eval, points, &runOpts)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = result.BestValue
+		if best == nil || better(result.BestValue, best.BestValue, opts.Maximize) {
+			best = result
+		}
+	}
+	best.RestartValues = values
+	return best, nil
+}
+
+// better reports whether value a is an improvement on b
+func better(a, b float64, maximize bool) bool {
+	if maximize {
+		return a > b
+	}
+	return a < b
+}
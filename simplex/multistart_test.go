@@ -0,0 +1,108 @@
+package simplex
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+// multiModal has a local minimum near each of (±2, ±2), of
+// which the one near (-2, -2) is the global minimum
+func multiModal(p *Point) float64 {
+	x, y := p.Terms[0], p.Terms[1]
+	return math.Pow(x*x-4, 2) + math.Pow(y*y-4, 2) + x + y
+}
+
+func TestOptimizeMultiStart(t *testing.T) {
+	opts := seededOptions(1)
+	opts.Bounds = [][2]float64{{-4, 4}, {-4, 4}}
+	opts.MaxIters = 500
+	opts.FTol = 1e-10
+	result, err := OptimizeMultiStart(multiModal, 2, 20, opts)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 20, len(result.RestartValues))
+	for _, v := range result.RestartValues {
+		assert.True(t, result.BestValue <= v)
+	}
+	assert.InDelta(t, -2.03, result.Best.Terms[0], 0.01)
+	assert.InDelta(t, -2.03, result.Best.Terms[1], 0.01)
+}
+
+func TestOptimizeMultiStartReproducible(t *testing.T) {
+	run := func(parallel bool) *Result {
+		opts := seededOptions(7)
+		opts.Bounds = [][2]float64{{-4, 4}, {-4, 4}}
+		opts.Parallel = parallel
+		result, err := OptimizeMultiStart(multiModal, 2, 5, opts)
+		assert.NoError(t, err)
+		return result
+	}
+	first := run(false)
+	assert.Equal(t, first.RestartValues, run(false).RestartValues)
+	assert.Equal(t, first.RestartValues, run(true).RestartValues)
+}
+
+func TestOptimizeMultiStartInvalid(t *testing.T) {
+	_, err := OptimizeMultiStart(multiModal, 2, 0, nil)
+	assert.Error(t, err)
+}
+
+func TestOptimizeMultiStartSeeded(t *testing.T) {
+	run := func(seed int64, mode MultiStartMode) (*Result, int) {
+		evals := 0
+		eval := func(p *Point) float64 {
+			evals++
+			return multiModal(p)
+		}
+		opts := seededOptions(seed)
+		opts.Bounds = [][2]float64{{-4, 4}, {-4, 4}}
+		opts.MaxIters = 500
+		opts.FTol = 1e-8
+		opts.MultiStart = mode
+		result, err := OptimizeMultiStart(eval, 2, 5, opts)
+		assert.NoError(t, err)
+		return result, evals
+	}
+
+	// global reports whether a run found the global minimum
+	// near (-2.03, -2.03), whose value is about -4.03; the
+	// next best local minimum is about -0.03
+	global := func(r *Result) int {
+		if r.BestValue < -4 {
+			return 1
+		}
+		return 0
+	}
+	independentEvals, seededEvals := 0, 0
+	independentGlobal, seededGlobal := 0, 0
+	for seed := int64(1); seed <= 20; seed++ {
+		result, evals := run(seed, MultiStartSeeded)
+		seededEvals += evals
+		seededGlobal += global(result)
+		independent, evals := run(seed, MultiStartIndependent)
+		independentEvals += evals
+		independentGlobal += global(independent)
+
+		// Every run keeps the best point so far as a vertex, so
+		// it can only improve on the earlier runs
+		for i := 1; i < len(result.RestartValues); i++ {
+			assert.True(t, result.RestartValues[i] <= result.RestartValues[i-1])
+		}
+		assert.Equal(t, result.RestartValues[len(result.RestartValues)-1], result.BestValue)
+
+		again, _ := run(seed, MultiStartSeeded)
+		assert.Equal(t, result.RestartValues, again.RestartValues)
+	}
+	// Seeded runs take fewer evaluations, but the quality is not
+	// the same: they keep returning to the basin of the first
+	// run's optimum, so they find the global minimum less often
+	// than independent runs
+	assert.True(t, seededEvals < independentEvals)
+	assert.True(t, seededGlobal < independentGlobal)
+
+	opts := seededOptions(1)
+	opts.MultiStart = MultiStartSeeded + 1
+	assert.Equal(t, `Options: unknown MultiStartMode(2)`, opts.Validate().Error())
+}
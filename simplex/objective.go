@@ -0,0 +1,47 @@
+package simplex
+
+// Objective is a function to be minimized which may carry state,
+// such as an evaluation counter or a cache
+type Objective interface {
+	// Evaluate returns the value of the objective at p. It
+	// must not modify p.
+	Evaluate(p *Point) float64
+}
+
+// ObjectiveFunc adapts a plain function to the Objective
+// interface
+type ObjectiveFunc func(p *Point) float64
+
+// Evaluate calls f(p)
+func (f ObjectiveFunc) Evaluate(p *Point) float64 {
+	return f(p)
+}
+
+// OptimizeObjective is like OptimizeWithOptions but minimizes
+// the given Objective. A FallibleObjective can stop the
+// optimization with StopFailures.
+func OptimizeObjective(obj Objective, dims int, opts *Options) (*Result, error) {
+	return OptimizeWithOptions(obj.Evaluate, dims, withAbort(obj, opts))
+}
+
+// OptimizeObjectiveFrom is like OptimizeFrom but minimizes the
+// given Objective. A FallibleObjective can stop the
+// optimization with StopFailures.
+func OptimizeObjectiveFrom(obj Objective, initial []*Point, opts *Options) (*Result, error) {
+	return OptimizeFrom(obj.Evaluate, initial, withAbort(obj, opts))
+}
+
+// withAbort returns a copy of opts which stops the optimization
+// when obj asks for it, or opts itself if obj never does
+func withAbort(obj Objective, opts *Options) *Options {
+	a, ok := obj.(aborter)
+	if !ok {
+		return opts
+	}
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	x := *opts
+	x.abort = a.aborted
+	return &x
+}
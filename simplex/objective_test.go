@@ -0,0 +1,48 @@
+package simplex
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+// countingObjective counts the number of times it is evaluated
+type countingObjective struct {
+	calls int
+}
+
+func (c *countingObjective) Evaluate(p *Point) float64 {
+	c.calls++
+	return math.Pow(p.Terms[0]-3, 2) + math.Pow(p.Terms[1]-4, 2)
+}
+
+func TestOptimizeObjective(t *testing.T) {
+	obj := &countingObjective{}
+	opts := seededOptions(1)
+	opts.FTol = 0
+	opts.MaxIters = 5
+	result, err := OptimizeObjective(obj, 2, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, result.Iterations)
+	// The initial simplex plus at least one evaluation per
+	// iteration
+	assert.True(t, obj.calls >= 3+5)
+}
+
+func TestObjectiveFunc(t *testing.T) {
+	f := func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-3, 2) + math.Pow(p.Terms[1]-4, 2)
+	}
+	initial := []*Point{
+		{Dims: 2, Terms: []float64{0, 0}},
+		{Dims: 2, Terms: []float64{1, 0}},
+		{Dims: 2, Terms: []float64{0, 1}},
+	}
+	fromFunc, err := OptimizeFrom(f, initial, nil)
+	assert.NoError(t, err)
+	fromObjective, err := OptimizeObjectiveFrom(ObjectiveFunc(f), initial, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, fromFunc.Best, fromObjective.Best)
+	assert.Equal(t, fromFunc.Iterations, fromObjective.Iterations)
+}
@@ -0,0 +1,67 @@
+// Package objectives provides standard benchmark functions for
+// testing optimizers. Each is a function of a *simplex.Point
+// that can be passed directly to the optimizer, and its global
+// minimum is given in its documentation.
+package objectives
+
+import (
+	"math"
+
+	"github.com/blake-wilson/simplex-optimizer/simplex"
+)
+
+// Sphere is the sum of the squares of the terms of p. It is
+// defined in any dimension and has its global minimum of 0 at
+// the origin.
+func Sphere(p *simplex.Point) float64 {
+	sum := 0.0
+	for _, v := range p.Terms {
+		sum += v * v
+	}
+	return sum
+}
+
+// Rosenbrock is the sum over consecutive pairs of terms of
+// 100(x[i+1] - x[i]²)² + (1 - x[i])², a narrow curved valley. It
+// is defined in 2 or more dimensions and has its global minimum
+// of 0 at (1, 1, ..., 1).
+func Rosenbrock(p *simplex.Point) float64 {
+	sum := 0.0
+	for i := 0; i+1 < len(p.Terms); i++ {
+		x, y := p.Terms[i], p.Terms[i+1]
+		sum += 100*math.Pow(y-x*x, 2) + math.Pow(1-x, 2)
+	}
+	return sum
+}
+
+// Rastrigin is 10n + Σ(x[i]² - 10cos(2πx[i])), a bowl covered in
+// a regular grid of local minima. It is defined in any dimension
+// n and has its global minimum of 0 at the origin.
+func Rastrigin(p *simplex.Point) float64 {
+	sum := 10 * float64(len(p.Terms))
+	for _, v := range p.Terms {
+		sum += v*v - 10*math.Cos(2*math.Pi*v)
+	}
+	return sum
+}
+
+// Ackley is a nearly flat outer region with many shallow local
+// minima around a deep central hole. It is defined in any
+// dimension and has its global minimum of 0 at the origin.
+func Ackley(p *simplex.Point) float64 {
+	n := float64(len(p.Terms))
+	squares, cosines := 0.0, 0.0
+	for _, v := range p.Terms {
+		squares += v * v
+		cosines += math.Cos(2 * math.Pi * v)
+	}
+	return -20*math.Exp(-0.2*math.Sqrt(squares/n)) - math.Exp(cosines/n) + 20 + math.E
+}
+
+// Beale is (1.5 - x + xy)² + (2.25 - x + xy²)² + (2.625 - x + xy³)².
+// It is only defined in 2 dimensions and has its global minimum
+// of 0 at (3, 0.5).
+func Beale(p *simplex.Point) float64 {
+	x, y := p.Terms[0], p.Terms[1]
+	return math.Pow(1.5-x+x*y, 2) + math.Pow(2.25-x+x*y*y, 2) + math.Pow(2.625-x+x*y*y*y, 2)
+}
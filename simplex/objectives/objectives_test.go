@@ -0,0 +1,61 @@
+package objectives
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+	"github.com/blake-wilson/simplex-optimizer/simplex"
+)
+
+func point(terms ...float64) *simplex.Point {
+	return &simplex.Point{Dims: len(terms), Terms: terms}
+}
+
+func TestMinima(t *testing.T) {
+	tests := []struct {
+		name    string
+		eval    func(p *simplex.Point) float64
+		minimum *simplex.Point
+	}{
+		{`Sphere`, Sphere, point(0, 0, 0)},
+		{`Rosenbrock`, Rosenbrock, point(1, 1, 1, 1)},
+		{`Rastrigin`, Rastrigin, point(0, 0)},
+		{`Ackley`, Ackley, point(0, 0, 0)},
+		{`Beale`, Beale, point(3, 0.5)},
+	}
+	for _, test := range tests {
+		if !assert.InDelta(t, 0, test.eval(test.minimum), 1e-12) {
+			t.Logf(`%s is not 0 at %v`, test.name, test.minimum.Terms)
+		}
+		// Nearby points are worse
+		moved := test.minimum.Clone()
+		moved.Terms[0] += 0.1
+		if !assert.True(t, test.eval(moved) > test.eval(test.minimum)) {
+			t.Logf(`%s is not minimized at %v`, test.name, test.minimum.Terms)
+		}
+	}
+}
+
+func TestOptimizeSphere(t *testing.T) {
+	opts := simplex.DefaultOptions()
+	opts.Rand = rand.New(rand.NewSource(1))
+	opts.FTol = 1e-12
+	opts.MaxIters = 5000
+	result, err := simplex.OptimizeWithOptions(Sphere, 4, opts)
+	assert.NoError(t, err)
+	assert.True(t, result.Converged)
+	assert.True(t, result.Best.ApproxEquals(point(0, 0, 0, 0), 1e-3))
+}
+
+func TestOptimizeRosenbrock(t *testing.T) {
+	opts := simplex.DefaultOptions()
+	opts.FTol = 0
+	opts.XTol = 1e-8
+	opts.MaxIters = 5000
+	initial := simplex.SimplexAround(point(-1.2, 1), 0.5)
+	result, err := simplex.OptimizeFrom(Rosenbrock, initial, opts)
+	assert.NoError(t, err)
+	assert.True(t, result.Converged)
+	assert.True(t, result.Best.ApproxEquals(point(1, 1), 1e-3))
+}
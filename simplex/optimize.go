@@ -0,0 +1,1440 @@
+// Package simplex minimizes functions of several variables using
+// the Nelder-Mead simplex method.
+package simplex
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gonum/stat"
+)
+
+var (
+	// ErrWorseThanAll is returned when a point is offered to a
+	// simplex whose value is no better than any existing value
+	ErrWorseThanAll = errors.New(`provided value is worse than all existing values`)
+	// ErrNoPoints is returned when an operation requiring at
+	// least one point is given none
+	ErrNoPoints = errors.New(`no points given`)
+	// ErrNotFull is returned when a point is offered to a
+	// simplex which does not yet hold Dimension+1 points, and so
+	// has no worst point to replace. Such a simplex is filled
+	// with SetPoint.
+	ErrNotFull = errors.New(`simplex does not hold Dimension+1 points`)
+)
+
+type Point struct {
+	Dims  int
+	Terms []float64
+}
+
+func NewPoint(dims int) *Point {
+	return &Point{
+		Dims:  dims,
+		Terms: make([]float64, dims),
+	}
+}
+
+// Simplex holds the points of a simplex sorted by their
+// evaluations, best first.
+//
+// Ties between equal evaluations are broken by age: a point
+// added by SetPoint or Improve goes after every point with the
+// same value, a new point whose value equals the worst value
+// cannot displace it, and Sort and the shrink step keep the
+// existing order of equal points. The order of a simplex
+// therefore depends only on the sequence of values it was
+// given, never on how the sort happens to run.
+type Simplex struct {
+	Points      []*Point
+	Dimension   int
+	Evaluations []float64
+	// Iterations is the number of iterations of the optimizer
+	// which produced the simplex, which is resumed from by
+	// OptimizeFromCheckpoint
+	Iterations     int
+	initialized    bool
+	numInitialized int
+}
+
+func NewSimplex(dim int) *Simplex {
+	return &Simplex{
+		Points:      make([]*Point, 0),
+		Evaluations: make([]float64, 0),
+		Dimension:   dim,
+	}
+}
+
+// BuildSimplex evaluates each of points with eval and returns
+// the simplex they form, sorted by value and ready for Step.
+// There must be one more point than the points have dimensions,
+// all with the same dimension, and the points are used as given
+// rather than copied.
+func BuildSimplex(eval func(p *Point) float64, points []*Point) (*Simplex, error) {
+	if err := validateInitial(points); err != nil {
+		return nil, err
+	}
+	s := NewSimplex(len(points) - 1)
+	for _, p := range points {
+		s.SetPoint(p, eval(p))
+	}
+	return s, nil
+}
+
+// ComputeCentroid returns the mean of the given points. It
+// panics if no points are given.
+func ComputeCentroid(points ...*Point) *Point {
+	weights := make([]float64, len(points))
+	for i := range weights {
+		weights[i] = 1
+	}
+	centroid, err := ComputeWeightedCentroid(weights, points...)
+	if err != nil {
+		panic(`ComputeCentroid: ` + err.Error())
+	}
+	return centroid
+}
+
+// ComputeWeightedCentroid returns the average of the given points
+// with each point scaled by its weight and the sum divided by the
+// total weight. There must be one weight per point and the
+// weights must not sum to zero, and every point must have the
+// same number of dimensions.
+func ComputeWeightedCentroid(weights []float64, points ...*Point) (*Point, error) {
+	if len(points) == 0 {
+		return nil, ErrNoPoints
+	}
+	if len(weights) != len(points) {
+		return nil, fmt.Errorf(`ComputeWeightedCentroid: got %d weights for %d points`,
+			len(weights), len(points))
+	}
+	if err := checkSumDims(`ComputeWeightedCentroid`, points); err != nil {
+		return nil, err
+	}
+	total := 0.0
+	centroid := NewPoint(points[0].Dims)
+	for i, p := range points {
+		total += weights[i]
+		for d := range centroid.Terms {
+			centroid.Terms[d] += weights[i] * p.Terms[d]
+		}
+	}
+	if total == 0 {
+		return nil, fmt.Errorf(`ComputeWeightedCentroid: weights sum to zero`)
+	}
+	return ScaleInto(centroid, centroid, 1/total), nil
+}
+
+// Centroid returns the centroid of every point of the simplex.
+// It panics if the simplex has no points.
+func (s *Simplex) Centroid() *Point {
+	return ComputeCentroid(s.Points...)
+}
+
+// CentroidExcludingWorst returns the centroid of every point of
+// the simplex except the worst, which is the center through
+// which the worst point is reflected
+func (s *Simplex) CentroidExcludingWorst() *Point {
+	return s.centroidExcludingWorstInto(NewPoint(s.Dimension), CentroidMean)
+}
+
+// centroidExcludingWorstInto is like CentroidExcludingWorst but
+// computes the centroid by method and stores it in dst, which is
+// returned
+func (s *Simplex) centroidExcludingWorstInto(dst *Point, method CentroidMethod) *Point {
+	points := s.Points[:len(s.Points)-1]
+	switch method {
+	case CentroidMedian:
+		return manhattanCentroidInto(dst, points)
+	case CentroidCompensatedMean:
+		return ScaleInto(dst, compensatedSumInto(dst, points), 1/float64(len(points)))
+	}
+	for d := range dst.Terms {
+		dst.Terms[d] = 0
+	}
+	for _, p := range points {
+		AddInto(dst, dst, p)
+	}
+	return ScaleInto(dst, dst, 1/float64(len(points)))
+}
+
+// StdDev returns the standard deviation of the Simplex's evaluated values.
+// It returns 0 for a simplex with fewer than two evaluations.
+func (s *Simplex) StdDev() float64 {
+	if len(s.Evaluations) < 2 {
+		return 0
+	}
+	return stat.StdDev(s.Evaluations, nil)
+}
+
+// Improve "improves" a simplex by replacing its worst
+// value with the given value. It panics if the value is
+// no better than the worst value or if the simplex is not
+// full; see TryImprove.
+func (s *Simplex) Improve(p *Point, value float64) {
+	if err := s.TryImprove(p, value); err != nil {
+		panic(`Improve: ` + err.Error())
+	}
+}
+
+// TryImprove is like Improve but returns ErrWorseThanAll
+// instead of panicking when the value is no better than
+// the worst value, and ErrNotFull when the simplex holds
+// fewer than Dimension+1 points or evaluations. The simplex
+// is unchanged in either case.
+func (s *Simplex) TryImprove(p *Point, value float64) error {
+	if len(s.Points) < s.Dimension+1 || len(s.Evaluations) < s.Dimension+1 {
+		return ErrNotFull
+	}
+	i := sort.Search(len(s.Evaluations[0:len(s.Evaluations)]),
+		func(i int) bool { return s.Evaluations[i] > value })
+	if i == len(s.Evaluations) {
+		return ErrWorseThanAll
+	}
+
+	// Prevent another slice allocation
+	// Do not copy the last element because it is the
+	// "worst" and will be trimmed
+	copy(s.Points[i+1:], s.Points[i:len(s.Points)-1])
+	copy(s.Evaluations[i+1:], s.Evaluations[i:len(s.Evaluations)-1])
+	s.Points[i] = p
+	s.Evaluations[i] = value
+	return nil
+}
+
+func (s *Simplex) Cost() float64 {
+	return s.Evaluations[0]
+}
+
+// Best returns a copy of the best point of the simplex and its
+// value. It panics if the simplex has no points.
+func (s *Simplex) Best() (*Point, float64) {
+	return s.Points[0].Clone(), s.Evaluations[0]
+}
+
+// Worst returns a copy of the worst point of the simplex and its
+// value. It panics if the simplex has no points.
+func (s *Simplex) Worst() (*Point, float64) {
+	last := len(s.Points) - 1
+	return s.Points[last].Clone(), s.Evaluations[last]
+}
+
+// SetPoint adds p with the given value to the simplex, keeping
+// the points sorted by value. Points with equal values keep the
+// order in which they were added. Once the simplex holds
+// Dimension+1 points, p replaces the current worst point.
+func (s *Simplex) SetPoint(p *Point, value float64) {
+	if s.numInitialized < s.Dimension+1 {
+		// make room for new value
+		s.Points = append(s.Points, p)
+		s.Evaluations = append(s.Evaluations, value)
+		s.numInitialized++
+	}
+	last := len(s.Points) - 1
+	i := sort.Search(last,
+		func(i int) bool { return value < s.Evaluations[i] })
+	copy(s.Points[i+1:], s.Points[i:last])
+	copy(s.Evaluations[i+1:], s.Evaluations[i:last])
+	s.Points[i] = p
+	s.Evaluations[i] = value
+}
+
+// Reset empties s so that it can be filled again by SetPoint
+// like a simplex returned by NewSimplex, keeping the capacity of
+// its slices to avoid allocating them again. The Dimension is
+// preserved and Iterations is set to zero.
+func (s *Simplex) Reset() {
+	s.Points = s.Points[:0]
+	s.Evaluations = s.Evaluations[:0]
+	s.Iterations = 0
+	s.initialized = false
+	s.numInitialized = 0
+}
+
+// IsSorted reports whether the evaluations of s are in
+// nondecreasing order, as every method of Simplex other than
+// Sort expects
+func (s *Simplex) IsSorted() bool {
+	for i := 1; i < len(s.Evaluations); i++ {
+		if s.Evaluations[i] < s.Evaluations[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// Sort restores the order of s after its points or evaluations
+// have been modified directly, moving each point together with
+// its evaluation. Points with equal values keep their relative
+// order. There must be one evaluation per point.
+func (s *Simplex) Sort() {
+	sort.Stable(byEvaluation{s.Points, s.Evaluations})
+}
+
+// byEvaluation sorts points together with their evaluations
+type byEvaluation struct {
+	points      []*Point
+	evaluations []float64
+}
+
+func (b byEvaluation) Len() int { return len(b.points) }
+
+func (b byEvaluation) Less(i, j int) bool { return b.evaluations[i] < b.evaluations[j] }
+
+func (b byEvaluation) Swap(i, j int) {
+	b.points[i], b.points[j] = b.points[j], b.points[i]
+	b.evaluations[i], b.evaluations[j] = b.evaluations[j], b.evaluations[i]
+}
+
+// String formats p as its coordinates in parentheses, such as
+// (1.5, -2), with up to 6 significant digits each
+func (p *Point) String() string {
+	if p == nil {
+		return `<nil>`
+	}
+	terms := make([]string, len(p.Terms))
+	for d, v := range p.Terms {
+		terms[d] = strconv.FormatFloat(v, 'g', 6, 64)
+	}
+	return `(` + strings.Join(terms, `, `) + `)`
+}
+
+// String lists the points of s in order, each followed by its
+// value, such as [(0, 0): 1, (1, 0): 2.5, (0, 1): 3]. Points
+// which have not been evaluated are listed without a value.
+func (s *Simplex) String() string {
+	points := make([]string, len(s.Points))
+	for i, p := range s.Points {
+		points[i] = p.String()
+		if i < len(s.Evaluations) {
+			points[i] += `: ` + strconv.FormatFloat(s.Evaluations[i], 'g', 6, 64)
+		}
+	}
+	return `[` + strings.Join(points, `, `) + `]`
+}
+
+// Clone returns a copy of p which shares no memory with it
+func (p *Point) Clone() *Point {
+	return &Point{
+		Dims:  p.Dims,
+		Terms: append([]float64(nil), p.Terms...),
+	}
+}
+
+// Equals reports whether p and other have the same dimension
+// and identical terms
+func (p *Point) Equals(other *Point) bool {
+	return p.ApproxEquals(other, 0)
+}
+
+// ApproxEquals reports whether p and other have the same
+// dimension and every pair of terms differs by at most tol
+func (p *Point) ApproxEquals(other *Point, tol float64) bool {
+	if p.Dims != other.Dims || len(p.Terms) != len(other.Terms) {
+		return false
+	}
+	for d, v := range p.Terms {
+		if !(math.Abs(v-other.Terms[d]) <= tol) {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a deep copy of s. Modifying the clone or any of
+// its points does not affect s.
+func (s *Simplex) Clone() *Simplex {
+	ret := &Simplex{
+		Points:         make([]*Point, len(s.Points)),
+		Dimension:      s.Dimension,
+		Evaluations:    append([]float64{}, s.Evaluations...),
+		Iterations:     s.Iterations,
+		initialized:    s.initialized,
+		numInitialized: s.numInitialized,
+	}
+	for i, p := range s.Points {
+		ret.Points[i] = p.Clone()
+	}
+	return ret
+}
+
+// SumPoints returns the sum of the given points. It panics
+// if no points are given or their dimensions differ; see
+// TrySumPoints.
+func SumPoints(points ...*Point) *Point {
+	if len(points) == 0 {
+		panic(`SumPoints: ` + ErrNoPoints.Error())
+	}
+	if err := checkSumDims(`SumPoints`, points); err != nil {
+		panic(err.Error())
+	}
+	sum, _ := TrySumPoints(points...)
+	return sum
+}
+
+// TrySumPoints is like SumPoints but returns ErrNoPoints
+// instead of panicking when no points are given, and an error
+// when their dimensions differ
+func TrySumPoints(points ...*Point) (*Point, error) {
+	if len(points) == 0 {
+		return nil, ErrNoPoints
+	}
+	if err := checkSumDims(`TrySumPoints`, points); err != nil {
+		return nil, err
+	}
+	acc := &Point{
+		Dims:  points[0].Dims,
+		Terms: make([]float64, points[0].Dims),
+	}
+	for d := 0; d < acc.Dims; d++ {
+		for _, p := range points {
+			acc.Terms[d] += p.Terms[d]
+		}
+	}
+	return acc, nil
+}
+
+// checkSumDims returns an error prefixed by op if the
+// dimensions of points differ
+func checkSumDims(op string, points []*Point) error {
+	for i, p := range points[1:] {
+		if p.Dims != points[0].Dims {
+			return fmt.Errorf(`%s: dimension mismatch, point %d has %d dimensions, expected %d`,
+				op, i+1, p.Dims, points[0].Dims)
+		}
+	}
+	return nil
+}
+
+// CompensatedSumPoints is like SumPoints but adds up each
+// coordinate with Neumaier's variant of Kahan summation, which
+// carries the rounding error of every addition forward so that
+// the error of the sum does not grow with the number of points
+// or with the spread of their magnitudes. It costs a few more
+// operations per term. It panics if no points are given or
+// their dimensions differ.
+func CompensatedSumPoints(points ...*Point) *Point {
+	if len(points) == 0 {
+		panic(`CompensatedSumPoints: ` + ErrNoPoints.Error())
+	}
+	if err := checkSumDims(`CompensatedSumPoints`, points); err != nil {
+		panic(err.Error())
+	}
+	return compensatedSumInto(NewPoint(points[0].Dims), points)
+}
+
+// compensatedSumInto is like CompensatedSumPoints but stores the
+// sum in dst, which is returned
+func compensatedSumInto(dst *Point, points []*Point) *Point {
+	for d := range dst.Terms {
+		sum, compensation := 0.0, 0.0
+		for _, p := range points {
+			v := p.Terms[d]
+			t := sum + v
+			// Recover the low order bits lost by whichever
+			// of the two terms is smaller
+			if math.Abs(sum) >= math.Abs(v) {
+				compensation += (sum - t) + v
+			} else {
+				compensation += (v - t) + sum
+			}
+			sum = t
+		}
+		dst.Terms[d] = sum + compensation
+	}
+	return dst
+}
+
+// ReflectPoint reflects p through center, scaling its distance
+// from center by coeff. It panics if the dimensions differ.
+func ReflectPoint(center, p *Point, coeff float64) *Point {
+	checkDims(`ReflectPoint`, center, p)
+	return lerpInto(NewPoint(p.Dims), center, p, -coeff)
+}
+
+// ContractPoint moves p towards center, scaling its distance
+// from center by coeff. It panics if the dimensions differ.
+func ContractPoint(center, p *Point, coeff float64) *Point {
+	checkDims(`ContractPoint`, center, p)
+	return lerpInto(NewPoint(p.Dims), center, p, coeff)
+}
+
+// BoundingBox returns the smallest and largest coordinate of
+// the points of the simplex along every dimension, as the terms
+// of lo and hi. It panics if the simplex has no points.
+func (s *Simplex) BoundingBox() (lo, hi *Point) {
+	if len(s.Points) == 0 {
+		panic(`BoundingBox: ` + ErrNoPoints.Error())
+	}
+	lo, hi = s.Points[0].Clone(), s.Points[0].Clone()
+	for _, p := range s.Points[1:] {
+		for d, v := range p.Terms {
+			lo.Terms[d] = math.Min(lo.Terms[d], v)
+			hi.Terms[d] = math.Max(hi.Terms[d], v)
+		}
+	}
+	return lo, hi
+}
+
+// Diameter returns the largest distance between any two
+// points of the simplex
+func (s *Simplex) Diameter() float64 {
+	diameter := 0.0
+	for i, p := range s.Points {
+		for _, q := range s.Points[i+1:] {
+			diameter = math.Max(diameter, p.Distance(q))
+		}
+	}
+	return diameter
+}
+
+// shortestEdge returns the smallest distance between any two
+// points of the simplex, or +Inf if it has fewer than two points
+func (s *Simplex) shortestEdge() float64 {
+	shortest := math.Inf(1)
+	for i, p := range s.Points {
+		for _, q := range s.Points[i+1:] {
+			shortest = math.Min(shortest, p.Distance(q))
+		}
+	}
+	return shortest
+}
+
+// Volume returns the n-dimensional volume of the simplex, which
+// is |det(E)| / n! for the matrix E whose rows are the edges from
+// the first point to each of the others. A simplex which has
+// collapsed onto a lower dimensional subspace has a volume of 0.
+// Volume panics unless the simplex holds Dimension+1 points.
+func (s *Simplex) Volume() float64 {
+	if len(s.Points) != s.Dimension+1 {
+		panic(fmt.Sprintf(`Volume: got %d points, expected %d`, len(s.Points), s.Dimension+1))
+	}
+	edges := make([][]float64, s.Dimension)
+	for i, p := range s.Points[1:] {
+		edges[i] = p.Sub(s.Points[0]).Terms
+	}
+	volume := math.Abs(determinant(edges))
+	for n := 2; n <= s.Dimension; n++ {
+		volume /= float64(n)
+	}
+	return volume
+}
+
+// determinant returns the determinant of the square matrix m,
+// computed by Gaussian elimination with partial pivoting. m is
+// overwritten. Pivots which are negligible relative to the
+// largest entry of m are treated as 0, so nearly singular
+// matrices have a determinant of 0.
+func determinant(m [][]float64) float64 {
+	scale := 0.0
+	for _, row := range m {
+		for _, v := range row {
+			scale = math.Max(scale, math.Abs(v))
+		}
+	}
+	det := 1.0
+	for col := range m {
+		pivot := col
+		for row := col + 1; row < len(m); row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(m[pivot][col]) <= 1e-12*scale {
+			return 0
+		}
+		if pivot != col {
+			m[pivot], m[col] = m[col], m[pivot]
+			det = -det
+		}
+		det *= m[col][col]
+		for row := col + 1; row < len(m); row++ {
+			factor := m[row][col] / m[col][col]
+			for c := col; c < len(m); c++ {
+				m[row][c] -= factor * m[col][c]
+			}
+		}
+	}
+	return det
+}
+
+// Validate checks that s holds Dimension+1 points, each with
+// Dimension terms, and one evaluation per point
+func (s *Simplex) Validate() error {
+	if len(s.Points) != s.Dimension+1 {
+		return fmt.Errorf(`Simplex: got %d points, expected %d for %d dimensions`,
+			len(s.Points), s.Dimension+1, s.Dimension)
+	}
+	if len(s.Evaluations) != len(s.Points) {
+		return fmt.Errorf(`Simplex: got %d evaluations for %d points`,
+			len(s.Evaluations), len(s.Points))
+	}
+	for i, p := range s.Points {
+		if p == nil {
+			return fmt.Errorf(`Simplex: point %d is nil`, i)
+		}
+		if p.Dims != s.Dimension {
+			return fmt.Errorf(`Simplex: point %d has Dims %d, expected %d`,
+				i, p.Dims, s.Dimension)
+		}
+		if len(p.Terms) != s.Dimension {
+			return fmt.Errorf(`Simplex: point %d has %d terms, expected %d`,
+				i, len(p.Terms), s.Dimension)
+		}
+	}
+	return nil
+}
+
+// shouldTerminate reports whether the spread of the simplex's
+// values has fallen below opts.FTol or below opts.FTolRel of
+// the best value, or its diameter has fallen below opts.XTol
+func shouldTerminate(s *Simplex, opts *Options) bool {
+	return convergence(s, opts, 1) != ``
+}
+
+// convergence returns the tolerance which the simplex has met,
+// or the empty string if it has not converged. The standard
+// deviation of the values is divided by spread before it is
+// compared with opts.FTol.
+func convergence(s *Simplex, opts *Options, spread float64) StopReason {
+	if opts.FTol > 0 && s.StdDev()/spread < opts.FTol {
+		return StopFTol
+	}
+	if opts.FTolRel > 0 && s.StdDev() < opts.FTolRel*math.Abs(s.Cost()) {
+		return StopFTolRel
+	}
+	if opts.XTol > 0 && s.Diameter() < opts.XTol {
+		return StopXTol
+	}
+	return ``
+}
+
+// holds reports whether s holds exactly the given points, in
+// order, with the given values, so that an iteration which
+// started from them made no progress
+func (s *Simplex) holds(points []*Point, values []float64) bool {
+	if len(points) != len(s.Points) || len(values) != len(s.Evaluations) {
+		return false
+	}
+	for i, p := range s.Points {
+		if s.Evaluations[i] != values[i] || (p != points[i] && !p.Equals(points[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// minEdgeSlack is the relative tolerance within which the
+// shortest edge of a simplex counts as at Options.MinEdge
+const minEdgeSlack = 1e-9
+
+// reachedTarget reports whether the best value cost of a simplex,
+// which is negated when maximize is set, has reached target
+func reachedTarget(cost, target float64, maximize bool) bool {
+	if maximize {
+		return -cost >= target
+	}
+	return cost <= target
+}
+
+// Optimize minimizes eval over a space of the given number of
+// dimensions with the DefaultOptions, starting from a random
+// simplex of dims+1 points. The Result holds a copy of the best
+// point found and its cost along with the final simplex and how
+// the optimization stopped.
+//
+// dims must be at least 1, and Optimize panics otherwise. In one
+// dimension the simplex is a segment of two points, which moves
+// along the line much like a bracketing line search.
+func Optimize(eval func(p *Point) float64, dims int) *Result {
+	// The default options are always valid, so the only error
+	// is a bad dims
+	result, err := OptimizeWithOptions(eval, dims, nil)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// OptimizeWithOptions is like Optimize but is configured by opts
+// and reports how the optimization stopped in the returned
+// Result. If opts is nil, DefaultOptions are used. An error is
+// returned if dims is less than 1.
+//
+// When opts.Maximize is set the best value is in the sign of
+// eval, but the final simplex holds negated evaluations so that
+// it remains sorted with the best point first.
+func OptimizeWithOptions(eval func(p *Point) float64, dims int, opts *Options) (*Result, error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkRandomDims(dims, opts); err != nil {
+		return nil, err
+	}
+	return optimize(eval, randomPoints(optionsRand(opts), dims, opts), nil, opts)
+}
+
+// checkRandomDims returns an error if a random initial simplex
+// in dims dimensions cannot be drawn according to opts
+func checkRandomDims(dims int, opts *Options) error {
+	if dims < 1 {
+		return fmt.Errorf(`need at least 1 dimension, got %d`, dims)
+	}
+	if opts.InitBox != nil && len(opts.InitBox) != dims {
+		return fmt.Errorf(`Options: got InitBox for %d dimensions, expected %d`,
+			len(opts.InitBox), dims)
+	}
+	if opts.InitBox != nil && len(opts.Bounds) == dims {
+		for d, b := range intersectBox(opts.InitBox, opts.Bounds) {
+			if !(b[0] < b[1]) {
+				return fmt.Errorf(`Options: InitBox for dimension %d does not overlap Bounds`, d)
+			}
+		}
+	}
+	return nil
+}
+
+// deterministicSeed seeds the source of randomness of
+// deterministic runs which do not provide one
+const deterministicSeed = 1
+
+// optionsRand returns opts.Rand, or a new source if it is not
+// set, which is time-seeded unless opts.Deterministic is set
+func optionsRand(opts *Options) *rand.Rand {
+	if opts.Rand != nil {
+		return opts.Rand
+	}
+	if opts.Deterministic {
+		return rand.New(rand.NewSource(deterministicSeed))
+	}
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+// randomPoints generates the dims+1 points of a random initial
+// simplex. If opts.InitBox is set the simplex is sized to it,
+// and if opts.InitialStep is set its edges along the axes have
+// that length. Otherwise the points are placed according to
+// opts.InitStrategy, drawn from opts.Bounds if they are set and
+// from [0, opts.Spread) in scaled units otherwise, and random
+// simplexes which are nearly degenerate are redrawn.
+func randomPoints(rng *rand.Rand, dims int, opts *Options) []*Point {
+	bounds := opts.Bounds
+	if bounds == nil || len(bounds) != dims {
+		bounds = spreadBounds(dims, opts.Spread, opts.Scale)
+	}
+	var points []*Point
+	switch {
+	case opts.InitBox != nil:
+		// Points clamped into Bounds one by one could collapse
+		// onto each other, so the box is cut down to Bounds
+		box := opts.InitBox
+		if len(opts.Bounds) == dims {
+			box = intersectBox(box, opts.Bounds)
+		}
+		points = initPointsInBox(rng, box, opts.InitFraction)
+	case opts.InitialStep > 0:
+		steps := make([]float64, dims)
+		for d := range steps {
+			steps[d] = opts.InitialStep
+			if len(opts.Scale) == dims {
+				steps[d] *= opts.Scale[d]
+			}
+		}
+		points = initPointsWithSteps(rng, bounds, steps)
+	}
+	if points != nil {
+		if len(opts.Bounds) == dims {
+			for _, p := range points {
+				constrain(p, opts.Bounds, opts.BoundaryMode)
+			}
+		}
+		return points
+	}
+	draw := func() []*Point {
+		switch opts.InitStrategy {
+		case InitLatinHypercube:
+			return initPointsLatinHypercube(rng, dims+1, bounds)
+		case InitGrid:
+			return initPointsGrid(dims+1, bounds)
+		}
+		if opts.Bounds == nil && opts.Scale == nil {
+			return initPoints(rng, dims, dims+1, opts.Spread)
+		}
+		return initPointsInBounds(rng, dims+1, bounds)
+	}
+	return nondegenerate(draw, bounds)
+}
+
+// OptimizeFrom is like OptimizeWithOptions but starts from the
+// given simplex instead of a random one. There must be dims+1
+// initial points which all have dims dimensions. The initial
+// points are used as given, even if they lie outside of
+// opts.Bounds.
+func OptimizeFrom(eval func(p *Point) float64, initial []*Point, opts *Options) (*Result, error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return optimize(eval, initial, nil, opts)
+}
+
+// validateInitial checks that points can form the vertices of
+// a simplex
+func validateInitial(points []*Point) error {
+	if len(points) == 0 {
+		return ErrNoPoints
+	}
+	if len(points) < 2 {
+		return fmt.Errorf(`need at least 2 initial points, got %d`, len(points))
+	}
+	if points[0] == nil {
+		return fmt.Errorf(`Simplex: point 0 is nil`)
+	}
+	s := &Simplex{
+		Points:      points,
+		Dimension:   points[0].Dims,
+		Evaluations: make([]float64, len(points)),
+	}
+	return s.Validate()
+}
+
+// OptimizeFromCheckpoint is like OptimizeFrom but continues from
+// a simplex saved by an earlier run, such as its FinalSimplex
+// decoded from JSON. The saved evaluations are reused rather than
+// recomputed, so opts.Maximize must match the earlier run, and
+// the checkpoint's Iterations count towards opts.MaxIters. Given
+// the same options, stopping a run and resuming it takes the same
+// steps as an uninterrupted run, except that the stall count of
+// opts.RestartOnStall starts over.
+func OptimizeFromCheckpoint(eval func(p *Point) float64, checkpoint *Simplex, opts *Options) (*Result, error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkpoint.Validate(); err != nil {
+		return nil, err
+	}
+	if checkpoint.Iterations < 0 {
+		return nil, fmt.Errorf(`Simplex: negative iteration count %d`, checkpoint.Iterations)
+	}
+	return optimize(eval, nil, checkpoint, opts)
+}
+
+// validateDims returns an error if the options which hold one
+// entry per dimension do not match dims
+func validateDims(dims int, opts *Options) error {
+	if opts.Bounds != nil && len(opts.Bounds) != dims {
+		return fmt.Errorf(`Options: got bounds for %d dimensions, expected %d`,
+			len(opts.Bounds), dims)
+	}
+	if err := validateScale(opts.Scale, dims); err != nil {
+		return err
+	}
+	if err := validateIntegerDims(opts.IntegerDims, dims); err != nil {
+		return err
+	}
+	if opts.Equality != nil {
+		return opts.Equality.validate(dims)
+	}
+	return nil
+}
+
+// optimize runs the Nelder-Mead algorithm starting from the
+// simplex formed by points or, if checkpoint is not nil, from a
+// copy of checkpoint without evaluating its points again
+func optimize(eval func(p *Point) float64, points []*Point, checkpoint *Simplex, opts *Options) (*Result, error) {
+	var dims int
+	if checkpoint != nil {
+		dims = checkpoint.Dimension
+	} else {
+		if err := validateInitial(points); err != nil {
+			return nil, err
+		}
+		dims = len(points) - 1
+	}
+	if err := validateDims(dims, opts); err != nil {
+		return nil, err
+	}
+	var calls int64
+	counted := eval
+	eval = func(p *Point) float64 {
+		atomic.AddInt64(&calls, 1)
+		return counted(p)
+	}
+	// exhausted reports whether evaluating n more points would
+	// exceed opts.MaxEvaluations
+	exhausted := func(n int) bool {
+		return opts.MaxEvaluations > 0 &&
+			atomic.LoadInt64(&calls)+int64(n) > int64(opts.MaxEvaluations)
+	}
+	var cache *CachedObjective
+	if opts.Cache {
+		cache = NewCachedObjective(ObjectiveFunc(eval), opts.CacheDigits)
+		eval = cache.Evaluate
+	}
+	if opts.Maximize {
+		objective := eval
+		eval = func(p *Point) float64 { return -objective(p) }
+	}
+	var bad int64
+	eval = finiteEval(eval, &bad)
+	if integerDims := opts.IntegerDims; integerDims != nil {
+		continuous := eval
+		eval = func(p *Point) float64 { return continuous(roundDims(p, integerDims)) }
+	}
+	// scale is Options.Scale; from here on the simplex, opts and
+	// eval work in the scaled coordinates
+	scale := opts.Scale
+	if scale != nil {
+		unscaled := eval
+		eval = func(p *Point) float64 { return unscaled(scaleUp(p, scale)) }
+		scaledPoints := make([]*Point, len(points))
+		for i, p := range points {
+			scaledPoints[i] = scaleDown(p, scale)
+		}
+		points = scaledPoints
+		if checkpoint != nil {
+			checkpoint = scaleSimplex(checkpoint, scale, scaleDown)
+		}
+		scaledOpts := *opts
+		scaledOpts.Bounds = scaleBounds(opts.Bounds, scale)
+		if opts.Equality != nil {
+			scaledOpts.Equality = opts.Equality.scaled(scale)
+		}
+		opts = &scaledOpts
+	}
+	if opts.Equality != nil && checkpoint == nil {
+		// The initial points are moved onto the subspace too,
+		// without modifying the caller's points
+		projected := make([]*Point, len(points))
+		for i, p := range points {
+			projected[i] = p.Clone()
+			opts.Equality.Project(projected[i])
+		}
+		points = projected
+	}
+	if opts.Adaptive {
+		adapted := *opts
+		adapted.Coefficients = AdaptiveCoefficients(dims)
+		opts = &adapted
+	}
+	var w *bufio.Writer
+	if opts.TraceWriter != nil {
+		w = bufio.NewWriter(opts.TraceWriter)
+	}
+	var history *historyWriter
+	if opts.HistoryWriter != nil {
+		history = newHistoryWriter(opts.HistoryWriter, dims, opts)
+	}
+
+	var simplex *Simplex
+	if checkpoint != nil {
+		simplex = checkpoint.Clone()
+		simplex.Sort()
+		simplex.numInitialized = len(simplex.Points)
+	} else {
+		simplex = NewSimplex(dims)
+		for i, value := range evaluateAll(eval, points, opts.Parallel) {
+			simplex.SetPoint(points[i], value)
+		}
+	}
+	result := &Result{
+		FinalSimplex: simplex,
+		Coefficients: opts.Coefficients,
+		Iterations:   simplex.Iterations,
+	}
+	// start is the iteration being resumed from, which the
+	// callback has already seen
+	start := result.Iterations
+	// bestValue is the best value seen before the current run of
+	// stalled iterations
+	bestValue, stalled := simplex.Cost(), 0
+	// centroid is reused by every iteration since none of the
+	// trial points computed from it keep a reference to it
+	centroid := NewPoint(dims)
+	// floored is set once a shrink reaches opts.MinEdge
+	floored := false
+	// spread divides the standard deviation compared with
+	// opts.FTol. With opts.NormalizeFTol it is the first usable
+	// standard deviation of the simplex's values.
+	spread, normalized := 1.0, !opts.NormalizeFTol
+	// stuck is set once an iteration leaves every point and
+	// value of the simplex as it was, after which every later
+	// iteration would do the same. before and beforeValues
+	// hold the simplex at the start of the iteration.
+	stuck := false
+	// patienceValue is the best value at the start of the
+	// waited iterations which have not improved on it by more
+	// than opts.FTol
+	patienceValue, waited := simplex.Cost(), 0
+	var before []*Point
+	var beforeValues []float64
+	record := func(op Operation) {
+		result.Operations = append(result.Operations, op)
+		opts.logf(LogDebug, `iteration %d: %v`, result.Iterations, op)
+	}
+	for {
+		if w != nil {
+			if err := writeSimplex(scaleSimplex(simplex, scale, scaleUp), w); err != nil {
+				return nil, err
+			}
+		}
+		if history != nil {
+			if err := history.writeRow(result.Iterations, scaleSimplex(simplex, scale, scaleUp)); err != nil {
+				return nil, err
+			}
+		}
+		if result.Iterations > start && opts.RecordPath {
+			best, _ := simplex.Best()
+			if scale != nil {
+				best = scaleUp(best, scale)
+			}
+			result.Path = append(result.Path, roundDims(best, opts.IntegerDims))
+			pathBest, pathWorst := simplex.Evaluations[0], simplex.Evaluations[len(simplex.Evaluations)-1]
+			if opts.Maximize {
+				pathBest, pathWorst = -pathBest, -pathWorst
+			}
+			result.BestValues = append(result.BestValues, pathBest)
+			result.WorstValues = append(result.WorstValues, pathWorst)
+		}
+		if result.Iterations > start && opts.events != nil {
+			opts.events <- newIterationEvent(result, scaleSimplex(simplex, scale, scaleUp), opts.Maximize)
+		}
+		if !normalized {
+			if sd := simplex.StdDev(); sd > 0 && !math.IsInf(sd, 0) && !math.IsNaN(sd) {
+				spread, normalized = sd, true
+			}
+		}
+		if opts.Patience > 0 && result.Iterations > start {
+			if (patienceValue-simplex.Cost())/spread > opts.FTol {
+				patienceValue, waited = simplex.Cost(), 0
+			} else {
+				waited++
+			}
+		}
+		// A failing objective stops the optimization first.
+		// The callback sees the simplex after each completed
+		// iteration.
+		if opts.abort != nil && opts.abort() {
+			result.StopReason = StopFailures
+		} else if result.Iterations > start && opts.Callback != nil &&
+			!opts.Callback(result.Iterations, scaleSimplex(simplex, scale, scaleUp)) {
+			result.StopReason = StopCallback
+		} else if reason := convergence(simplex, opts, spread); reason != `` {
+			result.Converged = true
+			result.StopReason = reason
+		} else if opts.Patience > 0 && waited >= opts.Patience {
+			result.Converged = true
+			result.StopReason = StopPatience
+		} else if floored {
+			result.Converged = true
+			result.StopReason = StopMinEdge
+		} else if stuck {
+			result.StopReason = StopNoProgress
+		} else if opts.TargetValue != nil && reachedTarget(simplex.Cost(), *opts.TargetValue, opts.Maximize) {
+			result.Converged = true
+			result.StopReason = StopTargetValue
+		} else if opts.TerminateFunc != nil &&
+			opts.TerminateFunc(scaleSimplex(simplex, scale, scaleUp), result.Iterations) {
+			result.Converged = true
+			result.StopReason = StopTerminateFunc
+		} else if result.Iterations >= opts.MaxIters {
+			result.StopReason = StopMaxIters
+		} else if exhausted(1) {
+			result.StopReason = StopMaxEvaluations
+		}
+		if result.StopReason != `` {
+			break
+		}
+		result.Iterations++
+		simplex.Iterations = result.Iterations
+		before = append(before[:0], simplex.Points...)
+		beforeValues = append(beforeValues[:0], simplex.Evaluations...)
+		op, ok := simplex.step(eval, opts, centroid, exhausted)
+		if !ok {
+			result.StopReason = StopMaxEvaluations
+			break
+		}
+		record(op)
+		// A shrink clamped by MinEdge leaves the shortest edge
+		// at the floor, up to rounding
+		floored = op == OpShrink && opts.MinEdge > 0 &&
+			simplex.shortestEdge() <= opts.MinEdge*(1+minEdgeSlack)
+		stuck = simplex.holds(before, beforeValues)
+		// A simplex rebuilt by a restart or a repair is reported
+		// as the iteration's operation in place of the step, so
+		// that it is seen together with the simplex it produced
+		restarted := false
+		restart := func() {
+			restartSimplex(simplex, eval, opts)
+			result.Restarts++
+			result.Operations[len(result.Operations)-1] = OpRestart
+			restarted = true
+		}
+		if opts.RestartOnStall > 0 {
+			if simplex.Cost() < bestValue-opts.StallTol {
+				bestValue, stalled = simplex.Cost(), 0
+			} else {
+				stalled++
+			}
+			if stalled >= opts.RestartOnStall && !exhausted(dims) {
+				restart()
+				stalled = 0
+			}
+		}
+		if floored && opts.RestartAtMinEdge && !exhausted(dims) {
+			restart()
+			floored = false
+		}
+		if stuck && opts.RestartOnNoProgress && !exhausted(dims) {
+			restart()
+			stuck = false
+		}
+		// A simplex which has converged is left for the
+		// tolerance check rather than repaired
+		if opts.RepairDuplicates && !exhausted(1) && simplex.Diameter() >= opts.XTol {
+			if i, j := findDuplicate(simplex, opts.XTol); j >= 0 {
+				repairDuplicate(simplex, i, j, eval, opts)
+				result.Repairs++
+				if !restarted {
+					result.Operations[len(result.Operations)-1] = OpRepair
+				}
+			}
+		}
+		if opts.RecordCentroid {
+			value := math.NaN()
+			if !exhausted(1) {
+				value = eval(simplex.Centroid())
+				if opts.Maximize {
+					value = -value
+				}
+			}
+			result.CentroidValues = append(result.CentroidValues, value)
+		}
+	}
+
+	if opts.GradientStep > 0 && !exhausted(2*dims) {
+		gradient := estimateGradient(eval, simplex.Points[0], simplex.Evaluations[0],
+			opts.GradientStep, opts.Bounds)
+		for d := range gradient.Terms {
+			if scale != nil {
+				gradient.Terms[d] /= scale[d]
+			}
+			if opts.Maximize {
+				gradient.Terms[d] = -gradient.Terms[d]
+			}
+		}
+		result.Gradient = gradient
+	}
+
+	if w != nil {
+		if err := w.Flush(); err != nil {
+			return nil, err
+		}
+	}
+	if history != nil {
+		if err := history.flush(); err != nil {
+			return nil, err
+		}
+	}
+	result.Evaluations = int(atomic.LoadInt64(&calls))
+	result.BadEvaluations = int(atomic.LoadInt64(&bad))
+	if cache != nil {
+		result.CacheHits = cache.Hits()
+	}
+	result.FinalSimplex = scaleSimplex(simplex, scale, scaleUp)
+	result.Best, result.BestValue = result.FinalSimplex.Best()
+	result.Best = roundDims(result.Best, opts.IntegerDims)
+	if opts.Maximize {
+		result.BestValue = -result.BestValue
+	}
+	opts.logf(LogInfo, `%s after %d iterations and %d evaluations: best value %v at %v`,
+		result.StopReason, result.Iterations, result.Evaluations, result.BestValue, result.Best)
+	return result, nil
+}
+
+// Step performs one Nelder-Mead iteration on the simplex, which
+// must hold Dimension+1 evaluated points, and returns the
+// operation it took. Trial points are evaluated with eval and
+// replace points of the simplex according to opts. Only the
+// Coefficients, CentroidMethod, ReflectionCenter, Bounds,
+// BoundaryMode, Equality, MinEdge and Parallel fields of opts
+// are used, and the coefficients are used as given even if
+// Adaptive is set. If opts is nil, DefaultOptions are used. The
+// simplex's Iterations count is incremented.
+//
+// A shrink is clamped by MinEdge as during optimization, so once
+// the shortest edge is at MinEdge, Step can return OpShrink
+// without moving any point.
+func (s *Simplex) Step(eval func(p *Point) float64, opts *Options) Operation {
+	if len(s.Points) != s.Dimension+1 || len(s.Evaluations) != s.Dimension+1 {
+		panic(`Step: ` + ErrNotFull.Error())
+	}
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	op, _ := s.step(eval, opts, NewPoint(s.Dimension), nil)
+	s.Iterations++
+	return op
+}
+
+// step performs the iteration of Step, storing the centroid in
+// centroid. If exhausted is not nil it reports whether n more
+// evaluations would exceed the evaluation budget, and step
+// returns false without finishing the iteration rather than
+// exceed it.
+func (s *Simplex) step(eval func(p *Point) float64, opts *Options, centroid *Point,
+	exhausted func(n int) bool) (Operation, bool) {
+	over := func(n int) bool { return exhausted != nil && exhausted(n) }
+	worst := s.Points[len(s.Points)-1]
+	s.reflectionCenterInto(centroid, opts)
+	reflected := ReflectPoint(centroid, worst, opts.Reflect)
+	feasible(reflected, opts)
+	expanded := lerpInto(NewPoint(s.Dimension), centroid, reflected, opts.Expand)
+	feasible(expanded, opts)
+	// In parallel mode the expanded point is evaluated
+	// alongside the reflected point in case it is needed,
+	// if the evaluation budget allows
+	var reflectedEval, expandedEval float64
+	speculate := opts.Parallel && !over(2)
+	if speculate {
+		values := evaluateAll(eval, []*Point{reflected, expanded}, true)
+		reflectedEval, expandedEval = values[0], values[1]
+	} else {
+		reflectedEval = eval(reflected)
+	}
+	// if reflected is better than the second worst point,
+	// but not better than the best, obtain new simplex which
+	// includes the reflected point
+	if reflectedEval < s.Evaluations[s.Dimension-1] &&
+		reflectedEval >= s.Evaluations[0] {
+		if err := s.TryImprove(reflected, reflectedEval); err != nil {
+			shrinkSimplex(s, eval, opts)
+			return OpShrink, true
+		}
+		return OpReflect, true
+	}
+	if reflectedEval < s.Evaluations[0] {
+		// reflected point is the best so far. Expand, unless
+		// the budget only allows keeping the reflected point
+		if !speculate {
+			expandedEval = math.Inf(1)
+			if !over(1) {
+				expandedEval = eval(expanded)
+			}
+		}
+		var err error
+		op := OpExpand
+		if expandedEval < reflectedEval {
+			err = s.TryImprove(expanded, expandedEval)
+		} else {
+			op = OpInitialReflect
+			err = s.TryImprove(reflected, reflectedEval)
+		}
+		if err != nil {
+			shrinkSimplex(s, eval, opts)
+			op = OpShrink
+		}
+		return op, true
+	}
+	if over(1) {
+		return 0, false
+	}
+	if reflectedEval < s.Evaluations[len(s.Points)-1] {
+		// The reflected point beat only the worst point, so
+		// contract outside, towards it, and keep the result
+		// if it is no worse than the reflected point
+		contracted := ContractPoint(centroid, reflected, opts.Contract)
+		feasible(contracted, opts)
+		contractedEval := eval(contracted)
+		if contractedEval <= reflectedEval {
+			if err := s.TryImprove(contracted, contractedEval); err == nil {
+				return OpContractOutside, true
+			}
+		}
+	} else {
+		// Otherwise contract inside, towards the worst point,
+		// and keep the result if it beats the worst point
+		contracted := ContractPoint(centroid, worst, opts.Contract)
+		feasible(contracted, opts)
+		contractedEval := eval(contracted)
+		if contractedEval < s.Evaluations[len(s.Points)-1] {
+			if err := s.TryImprove(contracted, contractedEval); err == nil {
+				return OpContract, true
+			}
+		}
+	}
+	if over(s.Dimension) {
+		return 0, false
+	}
+	shrinkSimplex(s, eval, opts)
+	return OpShrink, true
+}
+
+// finiteEval wraps eval so that NaN and infinite values are
+// replaced by +Inf, which is worse than any finite value and so
+// never accepted in place of one. Each such value increments
+// bad, which may happen concurrently.
+func finiteEval(eval func(p *Point) float64, bad *int64) func(p *Point) float64 {
+	return func(p *Point) float64 {
+		value := eval(p)
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			atomic.AddInt64(bad, 1)
+			return math.Inf(1)
+		}
+		return value
+	}
+}
+
+// shrinkSimplex moves every point of the simplex except the
+// best towards the best point, re-evaluates them and restores
+// the simplex's ordering. The points are evaluated concurrently
+// if opts.Parallel is set. If opts.MinEdge is set the simplex
+// shrinks no further than to make its shortest edge MinEdge.
+func shrinkSimplex(simplex *Simplex, eval func(p *Point) float64, opts *Options) {
+	coeff := opts.Shrink
+	if opts.MinEdge > 0 {
+		if shortest := simplex.shortestEdge(); shortest > 0 {
+			coeff = math.Max(coeff, opts.MinEdge/shortest)
+		}
+		if coeff >= 1 {
+			// The simplex is already at the floor
+			return
+		}
+	}
+	best := simplex.Points[0]
+	shrunk := make([]*Point, len(simplex.Points)-1)
+	for i, p := range simplex.Points[1:] {
+		shrunk[i] = lerpInto(NewPoint(p.Dims), best, p, coeff)
+		feasible(shrunk[i], opts)
+	}
+	for i, value := range evaluateAll(eval, shrunk, opts.Parallel) {
+		simplex.Points[i+1] = shrunk[i]
+		simplex.Evaluations[i+1] = value
+	}
+	simplex.Sort()
+}
+
+// restartSimplex replaces the simplex with a fresh one built
+// around its best point using opts.RestartStep
+func restartSimplex(simplex *Simplex, eval func(p *Point) float64, opts *Options) {
+	opts.logf(LogDebug, `iteration %d: restart`, simplex.Iterations)
+	best, bestValue := simplex.Points[0], simplex.Cost()
+	points := SimplexAround(best, opts.RestartStep)
+	for _, p := range points[1:] {
+		feasible(p, opts)
+	}
+	simplex.Points = simplex.Points[:0]
+	simplex.Evaluations = simplex.Evaluations[:0]
+	simplex.numInitialized = 0
+	// The best point keeps its value rather than being
+	// evaluated again
+	simplex.SetPoint(points[0], bestValue)
+	for i, value := range evaluateAll(eval, points[1:], opts.Parallel) {
+		simplex.SetPoint(points[i+1], value)
+	}
+}
+
+// evaluateAll returns the value of each of points, in order.
+// The points are evaluated concurrently if parallel is set.
+func evaluateAll(eval func(p *Point) float64, points []*Point, parallel bool) []float64 {
+	values := make([]float64, len(points))
+	if !parallel {
+		for i, p := range points {
+			values[i] = eval(p)
+		}
+		return values
+	}
+	// Each goroutine writes only its own element of values, and
+	// none are read until all of them have finished
+	var wg sync.WaitGroup
+	for i, p := range points {
+		wg.Add(1)
+		go func(i int, p *Point) {
+			defer wg.Done()
+			values[i] = eval(p)
+		}(i, p)
+	}
+	wg.Wait()
+	return values
+}
+
+// initPoints generates count random points whose terms are drawn
+// uniformly from [0, spread) using rng
+func initPoints(rng *rand.Rand, dim, count int, spread float64) []*Point {
+	points := make([]*Point, count)
+	for i := 0; i < count; i++ {
+		points[i] = NewPoint(dim)
+		for d := 0; d < dim; d++ {
+			r := rng.Float64() * spread
+			points[i].Terms[d] = r
+		}
+	}
+	return points
+}
+
+// SubtractMean constructs a new simplex whose
+// points have been recentered around 0
+func (s *Simplex) SubtractMean() *Simplex {
+	averages := make([]float64, s.Dimension)
+	for _, p := range s.Points {
+		for d := 0; d < s.Dimension; d++ {
+			averages[d] += p.Terms[d]
+		}
+	}
+	for i := 0; i < len(averages); i++ {
+		averages[i] = averages[i] / float64(len(s.Points))
+	}
+
+	s2 := NewSimplex(s.Dimension)
+	s2.Points = make([]*Point, len(s.Points))
+	for i, p := range s.Points {
+		s2.Points[i] = NewPoint(s.Dimension)
+		for d := 0; d < s.Dimension; d++ {
+			s2.Points[i].Terms[d] = p.Terms[d] - averages[d]
+		}
+	}
+	return s2
+}
+
+// TranslateToPositive translates all the coordinates of the given
+// Simplex's points to nonnegative values
+func (s *Simplex) TranslateToPositive() *Simplex {
+	mins := make([]float64, s.Dimension)
+	for d := 0; d < len(s.Points[0].Terms); d++ {
+		mins[d] = s.Points[0].Terms[d]
+	}
+	for _, p := range s.Points[1:] {
+		for d := 0; d < len(p.Terms); d++ {
+			if p.Terms[d] < mins[d] {
+				mins[d] = p.Terms[d]
+			}
+		}
+	}
+	s2 := NewSimplex(s.Dimension)
+	newPoints := make([]*Point, len(s.Points))
+	for i, p := range s.Points {
+		newPoints[i] = NewPoint(s.Dimension)
+		for d := 0; d < len(p.Terms); d++ {
+			newPoints[i].Terms[d] = p.Terms[d] - mins[d]
+		}
+	}
+	s2.Points = newPoints
+	return s2
+}
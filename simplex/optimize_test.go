@@ -0,0 +1,1641 @@
+package simplex
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+// seededOptions returns the default options with a deterministic
+// source of randomness so optimization runs are reproducible
+func seededOptions(seed int64) *Options {
+	opts := DefaultOptions()
+	opts.Rand = rand.New(rand.NewSource(seed))
+	return opts
+}
+
+func TestReflectPoint(t *testing.T) {
+	center := &Point{
+		Dims:  3,
+		Terms: []float64{0, 2, 0},
+	}
+	subject := &Point{
+		Dims:  3,
+		Terms: []float64{0, 1, 0},
+	}
+	expected := &Point{
+		Dims:  3,
+		Terms: []float64{0, 3, 0},
+	}
+	assert.Equal(t, expected, ReflectPoint(center, subject, 1))
+}
+
+// panicMessage returns the value f panics with, or nil if it
+// returns normally
+func panicMessage(f func()) (msg interface{}) {
+	defer func() { msg = recover() }()
+	f()
+	return nil
+}
+
+func TestReflectContractDimensionMismatch(t *testing.T) {
+	center := &Point{Dims: 3, Terms: []float64{0, 2, 0}}
+	p := &Point{Dims: 2, Terms: []float64{0, 1}}
+	assert.Equal(t, `ReflectPoint: dimension mismatch, 3 != 2`,
+		panicMessage(func() { ReflectPoint(center, p, 1) }))
+	assert.Equal(t, `ContractPoint: dimension mismatch, 3 != 2`,
+		panicMessage(func() { ContractPoint(center, p, 0.5) }))
+}
+
+func TestComputeCentroid(t *testing.T) {
+	points := []*Point{{
+		Dims:  2,
+		Terms: []float64{1, 1},
+	}, {
+		Dims:  2,
+		Terms: []float64{2, 3},
+	}, {
+		Dims:  2,
+		Terms: []float64{10, 11},
+	}}
+
+	expected := &Point{
+		Dims:  2,
+		Terms: []float64{13.0 / 3.0, 5},
+	}
+	assert.True(t, expected.ApproxEquals(ComputeCentroid(points...), 1e-12))
+}
+
+func TestComputeWeightedCentroid(t *testing.T) {
+	points := []*Point{
+		{Dims: 2, Terms: []float64{0, 0}},
+		{Dims: 2, Terms: []float64{4, 0}},
+		{Dims: 2, Terms: []float64{0, 8}},
+	}
+	// Equal weights give the unweighted centroid
+	centroid, err := ComputeWeightedCentroid([]float64{2, 2, 2}, points...)
+	assert.NoError(t, err)
+	assert.Equal(t, ComputeCentroid(points...), centroid)
+
+	// Down-weighting the last point pulls the centroid away
+	// from it
+	centroid, err = ComputeWeightedCentroid([]float64{1, 2, 1}, points...)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{2, 2}, centroid.Terms)
+
+	_, err = ComputeWeightedCentroid([]float64{1, 1}, points...)
+	assert.EqualError(t, err, `ComputeWeightedCentroid: got 2 weights for 3 points`)
+	_, err = ComputeWeightedCentroid([]float64{1, -1, 0}, points...)
+	assert.EqualError(t, err, `ComputeWeightedCentroid: weights sum to zero`)
+	_, err = ComputeWeightedCentroid([]float64{1, 1}, NewPoint(2), NewPoint(3))
+	assert.EqualError(t, err,
+		`ComputeWeightedCentroid: dimension mismatch, point 1 has 3 dimensions, expected 2`)
+	_, err = ComputeWeightedCentroid(nil)
+	assert.Equal(t, ErrNoPoints, err)
+	assert.Panics(t, func() { ComputeCentroid() })
+}
+
+func TestCentroidExcludingWorst(t *testing.T) {
+	s := NewSimplex(2)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{1, 1}}, 1)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{100, 100}}, 50)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{3, 5}}, 2)
+
+	expected := &Point{Dims: 2, Terms: []float64{2, 3}}
+	assert.Equal(t, expected, s.CentroidExcludingWorst())
+}
+
+func TestSimplexCentroid(t *testing.T) {
+	s := NewSimplex(2)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{1, 1}}, 1)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{100, 100}}, 50)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{4, 7}}, 2)
+
+	assert.Equal(t, ComputeCentroid(s.Points...), s.Centroid())
+	assert.Equal(t, []float64{35, 36}, s.Centroid().Terms)
+	assert.Panics(t, func() { NewSimplex(2).Centroid() })
+}
+
+func TestSimplexBoundingBox(t *testing.T) {
+	s := NewSimplex(3)
+	s.SetPoint(&Point{Dims: 3, Terms: []float64{1, -2, 5}}, 0)
+	s.SetPoint(&Point{Dims: 3, Terms: []float64{4, 0, 5}}, 1)
+	s.SetPoint(&Point{Dims: 3, Terms: []float64{-3, 7, 5}}, 2)
+	s.SetPoint(&Point{Dims: 3, Terms: []float64{0, 1, 5}}, 3)
+
+	lo, hi := s.BoundingBox()
+	assert.Equal(t, []float64{-3, -2, 5}, lo.Terms)
+	assert.Equal(t, []float64{4, 7, 5}, hi.Terms)
+	// The corners are copies
+	lo.Terms[1] = 100
+	assert.Equal(t, []float64{1, -2, 5}, s.Points[0].Terms)
+	assert.Panics(t, func() { NewSimplex(3).BoundingBox() })
+}
+
+func TestStdDev(t *testing.T) {
+	s := NewSimplex(1)
+	assert.Equal(t, 0.0, s.StdDev())
+	s.SetPoint(&Point{Dims: 1, Terms: []float64{0}}, 1)
+	assert.Equal(t, 0.0, s.StdDev())
+	s.SetPoint(&Point{Dims: 1, Terms: []float64{1}}, 3)
+	assert.Equal(t, math.Sqrt2, s.StdDev())
+}
+
+func TestImproveSimplex(t *testing.T) {
+	points := []*Point{{
+		Dims:  2,
+		Terms: []float64{0, 0},
+	}, {
+		Dims:  2,
+		Terms: []float64{10, 20},
+	}, {
+		Dims:  2,
+		Terms: []float64{20, 10},
+	}}
+	s := NewSimplex(2)
+	evals := []float64{10, 20, 30}
+	for i, p := range points {
+		s.SetPoint(p, evals[i])
+	}
+
+	// If cost is better than all of the existing points,
+	// point should be inserted as first evaluation
+	add1 := &Point{
+		Dims:  2,
+		Terms: []float64{20, 20},
+	}
+	s.Improve(add1, 5)
+
+	expected := append([]*Point{add1}, points[:2]...)
+	assert.Equal(t, expected, s.Points)
+
+	// Cost in the middle => point should be inserted in the middle.
+	// Current costs = 5, 10, 20
+	add2 := &Point{
+		Dims:  2,
+		Terms: []float64{-1, -2},
+	}
+	s.Improve(add2, 7)
+	expected = []*Point{add1, add2, points[0]}
+	assert.Equal(t, expected, s.Points)
+
+	// Cost at the end => point should be inserted at the end.
+	// Current costs = 5, 7, 10
+	add3 := &Point{
+		Dims:  2,
+		Terms: []float64{100, 200},
+	}
+	s.Improve(add3, 9)
+	expected = []*Point{add1, add2, add3}
+	assert.Equal(t, expected, s.Points)
+
+	// Cost higher than any existing evaluation should panic
+	assert.Panics(t, func() {
+		s.Improve(&Point{Dims: 2, Terms: []float64{10, 20}}, 100)
+	})
+}
+
+func TestBuildSimplex(t *testing.T) {
+	eval := func(p *Point) float64 { return p.Dot(p) }
+	points := []*Point{
+		{Dims: 2, Terms: []float64{3, 0}},
+		{Dims: 2, Terms: []float64{1, 0}},
+		{Dims: 2, Terms: []float64{0, 2}},
+	}
+	s, err := BuildSimplex(eval, points)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Validate())
+	assert.True(t, s.IsSorted())
+	assert.Equal(t, []float64{1, 4, 9}, s.Evaluations)
+	assert.Equal(t, []*Point{points[1], points[2], points[0]}, s.Points)
+	// The simplex is ready to step
+	s.Step(eval, nil)
+	assert.Equal(t, 1, s.Iterations)
+	assert.True(t, s.IsSorted())
+
+	_, err = BuildSimplex(eval, points[:2])
+	assert.EqualError(t, err, `Simplex: got 2 points, expected 3 for 2 dimensions`)
+	_, err = BuildSimplex(eval, nil)
+	assert.Equal(t, ErrNoPoints, err)
+	_, err = BuildSimplex(eval, []*Point{nil, points[0], points[1]})
+	assert.EqualError(t, err, `Simplex: point 0 is nil`)
+}
+
+func TestSimplexReset(t *testing.T) {
+	eval := func(p *Point) float64 { return p.Dot(p) }
+	fill := func(s *Simplex) {
+		for _, p := range SimplexAround(&Point{Dims: 2, Terms: []float64{3, 1}}, 1) {
+			s.SetPoint(p, eval(p))
+		}
+	}
+	fresh := NewSimplex(2)
+	fill(fresh)
+
+	s := NewSimplex(2)
+	fill(s)
+	for i := 0; i < 5; i++ {
+		s.Step(eval, nil)
+	}
+	points := &s.Points[:cap(s.Points)][0]
+	s.Reset()
+	assert.Equal(t, 2, s.Dimension)
+	assert.Equal(t, 0, len(s.Points))
+	assert.Equal(t, 0, len(s.Evaluations))
+	assert.Equal(t, 0, s.Iterations)
+
+	fill(s)
+	assert.Equal(t, fresh.Points, s.Points)
+	assert.Equal(t, fresh.Evaluations, s.Evaluations)
+	// The points are stored in the same slice as before
+	assert.True(t, points == &s.Points[0])
+	for i := 0; i < 5; i++ {
+		fresh.Step(eval, nil)
+		s.Step(eval, nil)
+	}
+	assert.Equal(t, fresh, s)
+}
+
+func TestSimplexSort(t *testing.T) {
+	s := NewSimplex(2)
+	a := &Point{Dims: 2, Terms: []float64{0, 0}}
+	b := &Point{Dims: 2, Terms: []float64{1, 0}}
+	c := &Point{Dims: 2, Terms: []float64{0, 1}}
+	s.SetPoint(a, 3)
+	s.SetPoint(b, 1)
+	s.SetPoint(c, 2)
+	assert.True(t, s.IsSorted())
+	assert.True(t, NewSimplex(2).IsSorted())
+
+	// Changing a value directly breaks the order
+	s.Evaluations[0] = 5
+	assert.False(t, s.IsSorted())
+	s.Sort()
+	assert.True(t, s.IsSorted())
+	assert.Equal(t, []*Point{c, a, b}, s.Points)
+	assert.Equal(t, []float64{2, 3, 5}, s.Evaluations)
+
+	// Equal values keep their order
+	s.Evaluations = []float64{4, 4, 1}
+	s.Sort()
+	assert.Equal(t, []*Point{b, c, a}, s.Points)
+}
+
+func TestSimplexBestWorst(t *testing.T) {
+	s := NewSimplex(2)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{4, 5}}, 7)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{1, 2}}, -3)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{0, 9}}, 2)
+
+	best, bestValue := s.Best()
+	assert.Equal(t, []float64{1, 2}, best.Terms)
+	assert.Equal(t, -3.0, bestValue)
+	worst, worstValue := s.Worst()
+	assert.Equal(t, []float64{4, 5}, worst.Terms)
+	assert.Equal(t, 7.0, worstValue)
+
+	// The points are copies
+	best.Terms[0] = 100
+	worst.Terms[0] = 100
+	assert.Equal(t, []float64{1, 2}, s.Points[0].Terms)
+	assert.Equal(t, []float64{4, 5}, s.Points[2].Terms)
+
+	assert.Panics(t, func() { NewSimplex(2).Best() })
+}
+
+func TestTryImproveNotFull(t *testing.T) {
+	// A 2-D simplex holding two of its three points has no
+	// worst point to replace
+	s := NewSimplex(2)
+	p1 := &Point{Dims: 2, Terms: []float64{0, 0}}
+	p2 := &Point{Dims: 2, Terms: []float64{1, 0}}
+	s.SetPoint(p1, 1)
+	s.SetPoint(p2, 2)
+
+	err := s.TryImprove(&Point{Dims: 2, Terms: []float64{0, 1}}, 0)
+	assert.Equal(t, ErrNotFull, err)
+	assert.Equal(t, []*Point{p1, p2}, s.Points)
+	assert.Equal(t, []float64{1, 2}, s.Evaluations)
+	assert.Panics(t, func() {
+		s.Improve(&Point{Dims: 2, Terms: []float64{0, 1}}, 0)
+	})
+	assert.Equal(t, ErrNotFull, NewSimplex(2).TryImprove(p1, 0))
+
+	// Once full, SetPoint and Improve agree
+	p3 := &Point{Dims: 2, Terms: []float64{0, 1}}
+	s.SetPoint(p3, 3)
+	assert.NoError(t, s.TryImprove(&Point{Dims: 2, Terms: []float64{1, 1}}, 0))
+	assert.Equal(t, []float64{0, 1, 2}, s.Evaluations)
+}
+
+func TestTryImproveWorseThanAll(t *testing.T) {
+	s := NewSimplex(1)
+	p1 := &Point{Dims: 1, Terms: []float64{1}}
+	p2 := &Point{Dims: 1, Terms: []float64{2}}
+	s.SetPoint(p1, 1)
+	s.SetPoint(p2, 2)
+
+	err := s.TryImprove(&Point{Dims: 1, Terms: []float64{3}}, 3)
+	assert.Equal(t, ErrWorseThanAll, err)
+	// The simplex is left untouched
+	assert.Equal(t, []*Point{p1, p2}, s.Points)
+	assert.Equal(t, []float64{1, 2}, s.Evaluations)
+
+	assert.NoError(t, s.TryImprove(&Point{Dims: 1, Terms: []float64{0}}, 0))
+	assert.Equal(t, []float64{0, 1}, s.Evaluations)
+}
+
+func TestTrySumPoints(t *testing.T) {
+	sum, err := TrySumPoints()
+	assert.Equal(t, ErrNoPoints, err)
+	assert.Nil(t, sum)
+	assert.Panics(t, func() { SumPoints() })
+
+	sum, err = TrySumPoints(&Point{Dims: 2, Terms: []float64{1, 2}},
+		&Point{Dims: 2, Terms: []float64{3, 4}})
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{4, 6}, sum.Terms)
+
+	_, err = TrySumPoints(&Point{Dims: 2, Terms: []float64{1, 2}},
+		&Point{Dims: 3, Terms: []float64{3, 4, 5}})
+	assert.EqualError(t, err, `TrySumPoints: dimension mismatch, point 1 has 3 dimensions, expected 2`)
+	msg := panicMessage(func() {
+		SumPoints(&Point{Dims: 1, Terms: []float64{1}}, &Point{Dims: 2, Terms: []float64{1, 2}})
+	})
+	assert.Equal(t, `SumPoints: dimension mismatch, point 1 has 2 dimensions, expected 1`, msg)
+}
+
+func TestPointString(t *testing.T) {
+	assert.Equal(t, `(1.5, -2)`, (&Point{Dims: 2, Terms: []float64{1.5, -2}}).String())
+	assert.Equal(t, `(3.14159, 1e-07, 123457)`,
+		(&Point{Dims: 3, Terms: []float64{math.Pi, 1e-7, 123456.7}}).String())
+	assert.Equal(t, `()`, NewPoint(0).String())
+	assert.Equal(t, `(0.25)`, fmt.Sprint(&Point{Dims: 1, Terms: []float64{0.25}}))
+}
+
+func TestSimplexString(t *testing.T) {
+	s := NewSimplex(2)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{1, 0}}, 2.5)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{0, 0}}, 1)
+	assert.Equal(t, `[(0, 0): 1, (1, 0): 2.5]`, s.String())
+
+	s.Points = append(s.Points, &Point{Dims: 2, Terms: []float64{0, 1}})
+	assert.Equal(t, `[(0, 0): 1, (1, 0): 2.5, (0, 1)]`, s.String())
+}
+
+func TestPointClone(t *testing.T) {
+	p := &Point{Dims: 2, Terms: []float64{1, 2}}
+	c := p.Clone()
+	assert.Equal(t, p, c)
+	c.Terms[0] = 100
+	assert.Equal(t, []float64{1, 2}, p.Terms)
+}
+
+func TestPointEquals(t *testing.T) {
+	p := &Point{Dims: 2, Terms: []float64{1, 2}}
+	tests := []struct {
+		other  *Point
+		tol    float64
+		equal  bool
+		approx bool
+	}{
+		{&Point{Dims: 2, Terms: []float64{1, 2}}, 0, true, true},
+		{&Point{Dims: 2, Terms: []float64{1, 2 + 1e-9}}, 1e-6, false, true},
+		{&Point{Dims: 2, Terms: []float64{1, 2.1}}, 1e-6, false, false},
+		{&Point{Dims: 2, Terms: []float64{1, math.NaN()}}, 1, false, false},
+		{&Point{Dims: 3, Terms: []float64{1, 2, 0}}, 1, false, false},
+		{&Point{Dims: 1, Terms: []float64{1}}, 1, false, false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.equal, p.Equals(test.other))
+		assert.Equal(t, test.approx, p.ApproxEquals(test.other, test.tol))
+	}
+}
+
+func TestSimplexClone(t *testing.T) {
+	s := NewSimplex(1)
+	s.SetPoint(&Point{Dims: 1, Terms: []float64{2}}, 4)
+	s.SetPoint(&Point{Dims: 1, Terms: []float64{1}}, 1)
+	c := s.Clone()
+	assert.Equal(t, s, c)
+
+	c.Points[0].Terms[0] = 100
+	c.Evaluations[0] = 100
+	c.SetPoint(&Point{Dims: 1, Terms: []float64{0}}, 0)
+	assert.Equal(t, []*Point{{Dims: 1, Terms: []float64{1}}, {Dims: 1, Terms: []float64{2}}},
+		s.Points)
+	assert.Equal(t, []float64{1, 4}, s.Evaluations)
+}
+
+func TestOptimizeHigherDimensions(t *testing.T) {
+	// 5-D paraboloid with its minimum of 0 at (1, 2, 3, 4, 5)
+	eval := func(p *Point) float64 {
+		sum := 0.0
+		for d, v := range p.Terms {
+			sum += math.Pow(v-float64(d+1), 2)
+		}
+		return sum
+	}
+	opts := seededOptions(1)
+	opts.FTol = 1e-12
+	opts.MaxIters = 5000
+	result, err := OptimizeWithOptions(eval, 5, opts)
+	assert.NoError(t, err)
+	s, best, cost := result.FinalSimplex, result.Best, result.BestValue
+
+	assert.True(t, result.Converged)
+	for d, v := range best.Terms {
+		assert.InDelta(t, float64(d+1), v, 1e-3)
+	}
+	assert.Equal(t, 5, s.Dimension)
+	assert.Equal(t, 6, len(s.Points))
+	assert.Equal(t, 6, len(s.Evaluations))
+	for _, p := range s.Points {
+		assert.Equal(t, 5, p.Dims)
+		assert.Equal(t, 5, len(p.Terms))
+	}
+	assert.True(t, cost >= 0)
+	assert.Equal(t, s.Cost(), cost)
+	assert.Equal(t, s.Points[0], best)
+}
+
+func TestOptimize(t *testing.T) {
+	eval := func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-3, 2) + math.Pow(p.Terms[1]-4, 2)
+	}
+	result := Optimize(eval, 2)
+	assert.Equal(t, 2, result.FinalSimplex.Dimension)
+	assert.Equal(t, result.FinalSimplex.Points[0].Terms, result.Best.Terms)
+	assert.Equal(t, eval(result.Best), result.BestValue)
+	assert.True(t, result.Iterations <= DefaultOptions().MaxIters)
+	assert.True(t, result.Evaluations >= 3+result.Iterations)
+	assert.NotEqual(t, StopReason(``), result.StopReason)
+}
+
+func TestOptimizeReturnsBestCopy(t *testing.T) {
+	eval := func(p *Point) float64 {
+		return math.Pow(p.Terms[1]-3, 2) + math.Pow(p.Terms[0]-4, 2)
+	}
+	result, err := OptimizeWithOptions(eval, 2, seededOptions(1))
+	assert.NoError(t, err)
+	s, best, cost := result.FinalSimplex, result.Best, result.BestValue
+
+	assert.Equal(t, s.Evaluations[0], cost)
+	assert.Equal(t, s.Points[0].Terms, best.Terms)
+
+	// Mutating the simplex must not affect the returned point
+	expected := append([]float64(nil), best.Terms...)
+	s.Points[0].Terms[0] += 100
+	assert.Equal(t, expected, best.Terms)
+}
+
+func TestOptimizeMaximize(t *testing.T) {
+	eval := func(p *Point) float64 {
+		return -math.Pow(p.Terms[0]-3, 2) - math.Pow(p.Terms[1]-4, 2)
+	}
+	opts := seededOptions(1)
+	opts.Maximize = true
+	opts.FTol = 1e-12
+	opts.MaxIters = 1000
+	result, err := OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+	s, best, cost := result.FinalSimplex, result.Best, result.BestValue
+
+	assert.InDelta(t, 3, best.Terms[0], 1e-3)
+	assert.InDelta(t, 4, best.Terms[1], 1e-3)
+
+	// The cost is reported in the sign of the objective while the
+	// simplex keeps the negated evaluations
+	assert.Equal(t, -s.Cost(), cost)
+	assert.Equal(t, s.Points[0], best)
+	assert.True(t, cost <= 0)
+}
+
+func TestOptimizeMaxIters(t *testing.T) {
+	// Rosenbrock's function takes many iterations to solve
+	eval := func(p *Point) float64 {
+		x, y := p.Terms[0], p.Terms[1]
+		return math.Pow(1-x, 2) + 100*math.Pow(y-x*x, 2)
+	}
+	opts := seededOptions(1)
+	opts.MaxIters = 3
+	result, err := OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+	assert.False(t, result.Converged)
+	assert.Equal(t, 3, result.Iterations)
+	assert.Equal(t, StopMaxIters, result.StopReason)
+}
+
+func TestOptimizeNaNOutsideRegion(t *testing.T) {
+	// The objective is only defined in the positive quadrant and
+	// its minimum lies close to the edge, so trial points leave
+	// the region
+	eval := func(p *Point) float64 {
+		x, y := p.Terms[0], p.Terms[1]
+		if x <= 0 || y <= 0 {
+			return math.NaN()
+		}
+		return math.Pow(x-0.1, 2) + math.Pow(y-0.2, 2)
+	}
+	initial := []*Point{
+		{Dims: 2, Terms: []float64{4, 4}},
+		{Dims: 2, Terms: []float64{5, 4}},
+		{Dims: 2, Terms: []float64{4, 5}},
+	}
+	opts := DefaultOptions()
+	opts.FTol = 1e-12
+	opts.MaxIters = 1000
+	result, err := OptimizeFrom(eval, initial, opts)
+	assert.NoError(t, err)
+	assert.True(t, result.Converged)
+	assert.True(t, result.BadEvaluations > 0)
+	assert.InDelta(t, 0.1, result.Best.Terms[0], 1e-3)
+	assert.InDelta(t, 0.2, result.Best.Terms[1], 1e-3)
+	for _, v := range result.FinalSimplex.Evaluations {
+		assert.False(t, math.IsInf(v, 0) || math.IsNaN(v))
+	}
+}
+
+func TestFiniteEval(t *testing.T) {
+	values := []float64{1, math.NaN(), math.Inf(1), math.Inf(-1), -2}
+	i := 0
+	var bad int64
+	eval := finiteEval(func(p *Point) float64 {
+		i++
+		return values[i-1]
+	}, &bad)
+	expected := []float64{1, math.Inf(1), math.Inf(1), math.Inf(1), -2}
+	for _, e := range expected {
+		assert.Equal(t, e, eval(nil))
+	}
+	assert.Equal(t, int64(3), bad)
+}
+
+func TestDiameter(t *testing.T) {
+	s := NewSimplex(2)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{0, 0}}, 0)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{3, 0}}, 1)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{0, 4}}, 2)
+	assert.Equal(t, 5.0, s.Diameter())
+}
+
+func TestVolume(t *testing.T) {
+	s := NewSimplex(2)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{1, 1}}, 0)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{5, 1}}, 1)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{1, 4}}, 2)
+	assert.Equal(t, 6.0, s.Volume())
+
+	// The unit 3-simplex has volume 1/3!
+	s = NewSimplex(3)
+	for i, terms := range [][]float64{{0, 0, 0}, {0, 1, 0}, {1, 0, 0}, {0, 0, 1}} {
+		s.SetPoint(&Point{Dims: 3, Terms: terms}, float64(i))
+	}
+	assert.InDelta(t, 1.0/6, s.Volume(), 1e-15)
+
+	// Collinear points enclose no area
+	s = NewSimplex(2)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{0, 0}}, 0)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{1, 1}}, 1)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{3, 3 + 1e-15}}, 2)
+	assert.Equal(t, 0.0, s.Volume())
+
+	assert.Panics(t, func() { NewSimplex(2).Volume() })
+}
+
+func TestShouldTerminateXTol(t *testing.T) {
+	// A shallow objective gives nearly identical values even
+	// though the points are far apart
+	eval := func(p *Point) float64 {
+		return 1e-6 * (p.Terms[0]*p.Terms[0] + p.Terms[1]*p.Terms[1])
+	}
+	build := func(size float64) *Simplex {
+		s := NewSimplex(2)
+		for _, terms := range [][]float64{{0, 0}, {size, 0}, {0, size}} {
+			p := &Point{Dims: 2, Terms: terms}
+			s.SetPoint(p, eval(p))
+		}
+		return s
+	}
+	s := build(10)
+
+	opts := DefaultOptions()
+	assert.True(t, shouldTerminate(s, opts))
+
+	opts.FTol = 0
+	opts.XTol = 1e-3
+	assert.False(t, shouldTerminate(s, opts))
+
+	// Once the simplex has actually shrunk it terminates
+	assert.True(t, shouldTerminate(build(1e-4), opts))
+
+	// A run stops on XTol only once the simplex is that small,
+	// while FTol alone stops it while it is still wide
+	opts.MaxIters = 1000
+	result, err := OptimizeFrom(eval, build(10).Points, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, StopXTol, result.StopReason)
+	assert.True(t, result.FinalSimplex.Diameter() <= opts.XTol)
+
+	opts = DefaultOptions()
+	result, err = OptimizeFrom(eval, build(10).Points, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, StopFTol, result.StopReason)
+	assert.True(t, result.FinalSimplex.Diameter() > 1)
+}
+
+func TestOptimizeFTolRel(t *testing.T) {
+	// The relative tolerance stops at the same point however
+	// the objective is scaled, while the absolute one does not
+	objective := func(factor float64) func(p *Point) float64 {
+		return func(p *Point) float64 {
+			return factor * (math.Pow(p.Terms[0]-1, 2) + math.Pow(p.Terms[1]-2, 2) + 1)
+		}
+	}
+	run := func(factor float64, configure func(*Options)) *Result {
+		opts := seededOptions(1)
+		opts.FTol = 0
+		opts.MaxIters = 1000
+		configure(opts)
+		result, err := OptimizeWithOptions(objective(factor), 2, opts)
+		assert.NoError(t, err)
+		return result
+	}
+
+	relative := func(o *Options) { o.FTolRel = 1e-6 }
+	small, large := run(1, relative), run(1e6, relative)
+	assert.Equal(t, StopFTolRel, small.StopReason)
+	assert.Equal(t, StopFTolRel, large.StopReason)
+	assert.Equal(t, small.Iterations, large.Iterations)
+	assert.Equal(t, small.Best, large.Best)
+	assert.InDelta(t, 1, small.BestValue, 1e-5)
+	assert.InDelta(t, 1e6, large.BestValue, 1e-5*1e6)
+
+	absolute := func(o *Options) { o.FTol = 1e-6 }
+	small, large = run(1, absolute), run(1e6, absolute)
+	assert.True(t, large.Iterations > small.Iterations)
+
+	opts := DefaultOptions()
+	opts.FTolRel = -1
+	assert.Error(t, opts.Validate())
+}
+
+func TestOptimizeCountsEvaluations(t *testing.T) {
+	calls := 0
+	// Every point other than the initial ones is worse than all
+	// of them, so each iteration reflects, contracts and then
+	// shrinks, evaluating 1 + 1 + 2 points
+	eval := func(p *Point) float64 {
+		calls++
+		switch {
+		case p.Terms[0] == 0 && p.Terms[1] == 0:
+			return 0
+		case p.Terms[0] == 1 && p.Terms[1] == 0:
+			return 1
+		case p.Terms[0] == 0 && p.Terms[1] == 1:
+			return 2
+		}
+		return 10
+	}
+	initial := []*Point{
+		{Dims: 2, Terms: []float64{0, 0}},
+		{Dims: 2, Terms: []float64{1, 0}},
+		{Dims: 2, Terms: []float64{0, 1}},
+	}
+	opts := DefaultOptions()
+	opts.FTol = 0
+	opts.MaxIters = 5
+	result, err := OptimizeFrom(eval, initial, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, calls, result.Evaluations)
+	assert.Equal(t, 3+4*5, result.Evaluations)
+	assert.Equal(t, []Operation{OpShrink, OpShrink, OpShrink, OpShrink, OpShrink},
+		result.Operations)
+
+	// In parallel mode the expanded point is also evaluated
+	calls = 0
+	opts.Parallel = true
+	var mu sync.Mutex
+	parallelEval := func(p *Point) float64 {
+		mu.Lock()
+		defer mu.Unlock()
+		return eval(p)
+	}
+	result, err = OptimizeFrom(parallelEval, initial, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, calls, result.Evaluations)
+	assert.Equal(t, 3+5*5, result.Evaluations)
+}
+
+func TestOptimizeMaxEvaluations(t *testing.T) {
+	// Rosenbrock's function takes many evaluations to solve
+	eval := func(p *Point) float64 {
+		x, y := p.Terms[0], p.Terms[1]
+		return math.Pow(1-x, 2) + 100*math.Pow(y-x*x, 2)
+	}
+	for _, budget := range []int{3, 4, 10, 25} {
+		for _, parallel := range []bool{false, true} {
+			opts := seededOptions(1)
+			opts.FTol = 0
+			opts.MaxIters = 1000
+			opts.MaxEvaluations = budget
+			opts.Parallel = parallel
+			result, err := OptimizeWithOptions(eval, 2, opts)
+			assert.NoError(t, err)
+			assert.Equal(t, StopMaxEvaluations, result.StopReason)
+			assert.False(t, result.Converged)
+			assert.True(t, result.Evaluations <= budget)
+		}
+	}
+}
+
+func TestOptimizeMaxEvaluationsShrink(t *testing.T) {
+	// As in TestOptimizeCountsEvaluations, each iteration costs
+	// 4 evaluations, and the shrink which would exceed the
+	// budget is not started
+	eval := func(p *Point) float64 {
+		switch {
+		case p.Terms[0] == 0 && p.Terms[1] == 0:
+			return 0
+		case p.Terms[0] == 1 && p.Terms[1] == 0:
+			return 1
+		case p.Terms[0] == 0 && p.Terms[1] == 1:
+			return 2
+		}
+		return 10
+	}
+	initial := []*Point{
+		{Dims: 2, Terms: []float64{0, 0}},
+		{Dims: 2, Terms: []float64{1, 0}},
+		{Dims: 2, Terms: []float64{0, 1}},
+	}
+	opts := DefaultOptions()
+	opts.FTol = 0
+	opts.MaxIters = 100
+	opts.MaxEvaluations = 3 + 4 + 3
+	result, err := OptimizeFrom(eval, initial, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, StopMaxEvaluations, result.StopReason)
+	assert.Equal(t, 3+4+2, result.Evaluations)
+	// The second iteration stopped before its shrink
+	assert.Equal(t, 2, result.Iterations)
+	assert.Equal(t, []Operation{OpShrink}, result.Operations)
+}
+
+func TestOptimizeMinEdge(t *testing.T) {
+	// As in TestOptimizeCountsEvaluations every iteration
+	// shrinks, halving the shortest edge from 1 to 0.5 and 0.25
+	// and then, clamped by the floor, to 0.2
+	eval := func(p *Point) float64 {
+		switch {
+		case p.Terms[0] == 0 && p.Terms[1] == 0:
+			return 0
+		case p.Terms[0] == 1 && p.Terms[1] == 0:
+			return 1
+		case p.Terms[0] == 0 && p.Terms[1] == 1:
+			return 2
+		}
+		return 10
+	}
+	initial := func() []*Point {
+		return []*Point{
+			{Dims: 2, Terms: []float64{0, 0}},
+			{Dims: 2, Terms: []float64{1, 0}},
+			{Dims: 2, Terms: []float64{0, 1}},
+		}
+	}
+	floor := 0.2
+	var shortest []float64
+	opts := DefaultOptions()
+	opts.FTol = 0
+	opts.MaxIters = 100
+	opts.MinEdge = floor
+	opts.Callback = func(iter int, s *Simplex) bool {
+		shortest = append(shortest, s.shortestEdge())
+		return true
+	}
+	result, err := OptimizeFrom(eval, initial(), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, StopMinEdge, result.StopReason)
+	assert.True(t, result.Converged)
+	assert.Equal(t, 3, result.Iterations)
+	assert.Equal(t, 3, len(shortest))
+	for _, edge := range shortest {
+		assert.True(t, edge >= floor*(1-1e-9))
+	}
+	assert.InDelta(t, floor, shortest[2], 1e-12)
+
+	// Restarting instead rebuilds the simplex with RestartStep
+	shortest = nil
+	opts.RestartAtMinEdge = true
+	opts.MaxIters = 10
+	result, err = OptimizeFrom(eval, initial(), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, StopMaxIters, result.StopReason)
+	assert.True(t, result.Restarts > 0)
+	for _, edge := range shortest {
+		assert.True(t, edge >= floor*(1-1e-9))
+	}
+
+	// Step honors the floor too, shrinking in place once the
+	// simplex is on it
+	s, err := BuildSimplex(eval, initial())
+	assert.NoError(t, err)
+	before := s.Clone()
+	opts = DefaultOptions()
+	opts.MinEdge = 1
+	assert.Equal(t, OpShrink, s.Step(eval, opts))
+	assert.Equal(t, before.Points, s.Points)
+	assert.Equal(t, before.Evaluations, s.Evaluations)
+
+	opts.MinEdge = -1
+	assert.Error(t, opts.Validate())
+}
+
+func TestOptimizeCallback(t *testing.T) {
+	eval := func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-3, 2) + math.Pow(p.Terms[1]-4, 2)
+	}
+	calls := 0
+	opts := seededOptions(1)
+	opts.FTol = 0
+	opts.MaxIters = 100
+	opts.Callback = func(iter int, s *Simplex) bool {
+		calls++
+		assert.Equal(t, calls, iter)
+		assert.Equal(t, 3, len(s.Points))
+		return iter < 5
+	}
+	result, err := OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, calls)
+	assert.Equal(t, 5, result.Iterations)
+	assert.Equal(t, StopCallback, result.StopReason)
+	assert.False(t, result.Converged)
+}
+
+func TestOptimizeTargetValue(t *testing.T) {
+	eval := func(p *Point) float64 { return p.Dot(p) + 1 }
+	target := 1.01
+	opts := seededOptions(1)
+	opts.FTol = 0
+	opts.MaxIters = 1000
+	opts.TargetValue = &target
+	result, err := OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, StopTargetValue, result.StopReason)
+	assert.True(t, result.Converged)
+	assert.True(t, result.BestValue <= target)
+	// It stopped at the first iteration which reached the
+	// target
+	opts = seededOptions(1)
+	opts.FTol = 0
+	opts.MaxIters = result.Iterations - 1
+	early, err := OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+	assert.True(t, early.BestValue > target)
+
+	// When maximizing the target is a lower bound
+	target = -1.01
+	opts = seededOptions(1)
+	opts.FTol = 0
+	opts.MaxIters = 1000
+	opts.Maximize = true
+	opts.TargetValue = &target
+	result, err = OptimizeWithOptions(func(p *Point) float64 { return -eval(p) }, 2, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, StopTargetValue, result.StopReason)
+	assert.True(t, result.BestValue >= target)
+}
+
+func TestOptimizeNormalizeFTol(t *testing.T) {
+	f := func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-1, 2) + 3*math.Pow(p.Terms[1]+2, 2)
+	}
+	run := func(scale float64, normalize bool) *Result {
+		opts := seededOptions(1)
+		opts.FTol = 1e-4
+		opts.MaxIters = 1000
+		opts.NormalizeFTol = normalize
+		result, err := OptimizeWithOptions(func(p *Point) float64 { return scale * f(p) }, 2, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, StopFTol, result.StopReason)
+		return result
+	}
+	// Without normalization the larger objective takes longer
+	// to reach the same raw spread
+	assert.True(t, run(1000, false).Iterations > run(1, false).Iterations)
+
+	small, large := run(1, true), run(1000, true)
+	assert.Equal(t, small.Iterations, large.Iterations)
+	assert.Equal(t, small.Best, large.Best)
+	// The reported costs are not normalized
+	assert.InDelta(t, 1000*small.BestValue, large.BestValue, 1e-9)
+}
+
+func TestOptimizePatience(t *testing.T) {
+	// Deterministic noise keeps the values of the simplex apart
+	// long after the best value has stopped improving
+	eval := func(p *Point) float64 {
+		_, noise := math.Modf(math.Abs(math.Sin(12.9898*p.Terms[0]+78.233*p.Terms[1])) * 43758.5453)
+		return p.Dot(p) + 0.1*noise
+	}
+	initial := func() []*Point {
+		return SimplexAround(&Point{Dims: 2, Terms: []float64{3, 2}}, 1)
+	}
+	opts := DefaultOptions()
+	opts.FTol = 1e-6
+	opts.MaxIters = 300
+	opts.RecordPath = true
+	full, err := OptimizeFrom(eval, initial(), opts)
+	assert.NoError(t, err)
+
+	// Find the first iteration which ends patience iterations
+	// without an improvement of more than FTol on the best
+	// value at their start
+	const patience = 5
+	expected, reference, waited := 0, full.BestValues[0], 0
+	for i, v := range full.BestValues[1:] {
+		if reference-v > opts.FTol {
+			reference, waited = v, 0
+		} else if waited++; waited == patience {
+			expected = i + 2
+			break
+		}
+	}
+	assert.True(t, expected > patience)
+	// Patience runs out before the values agree to within FTol
+	assert.True(t, expected < full.Iterations)
+
+	opts.Patience = patience
+	result, err := OptimizeFrom(eval, initial(), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, StopPatience, result.StopReason)
+	assert.True(t, result.Converged)
+	assert.Equal(t, expected, result.Iterations)
+	assert.Equal(t, full.BestValues[:expected], result.BestValues)
+
+	opts.Patience = -1
+	assert.EqualError(t, opts.Validate(), `Options: Patience must not be negative, got -1`)
+}
+
+func TestOptimizeNoProgress(t *testing.T) {
+	// On a flat objective every iteration shrinks the simplex
+	// until rounding stops it from getting any smaller
+	flat := func(p *Point) float64 { return 1 }
+	opts := seededOptions(1)
+	opts.FTol = 0
+	opts.MaxIters = 100000
+	result, err := OptimizeWithOptions(flat, 2, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, StopNoProgress, result.StopReason)
+	assert.False(t, result.Converged)
+	assert.True(t, result.Iterations < 5000)
+	assert.True(t, result.FinalSimplex.Diameter() < 1e-12)
+
+	// Restarting instead keeps going until the iteration limit
+	opts = seededOptions(1)
+	opts.FTol = 0
+	opts.MaxIters = 5000
+	opts.RestartOnNoProgress = true
+	result, err = OptimizeWithOptions(flat, 2, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, StopMaxIters, result.StopReason)
+	assert.True(t, result.Restarts > 0)
+
+	opts.RestartStep = 0
+	assert.Error(t, opts.Validate())
+}
+
+func TestOptimizeTerminateFunc(t *testing.T) {
+	eval := func(p *Point) float64 { return p.Dot(p) + 1 }
+	target := 1.5
+	var iters []int
+	opts := seededOptions(1)
+	opts.FTol = 0
+	opts.MaxIters = 1000
+	opts.TerminateFunc = func(s *Simplex, iter int) bool {
+		iters = append(iters, iter)
+		return s.Cost() < target
+	}
+	result, err := OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, StopTerminateFunc, result.StopReason)
+	assert.True(t, result.Converged)
+	assert.True(t, result.BestValue < target)
+	// It is checked before every iteration, including the
+	// first, and stops as soon as the target is reached
+	assert.Equal(t, result.Iterations+1, len(iters))
+	assert.Equal(t, 0, iters[0])
+
+	// It takes precedence over MaxIters on the same iteration
+	opts = seededOptions(1)
+	opts.FTol = 0
+	opts.MaxIters = result.Iterations
+	opts.TerminateFunc = func(s *Simplex, iter int) bool { return s.Cost() < target }
+	capped, err := OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, StopTerminateFunc, capped.StopReason)
+}
+
+func TestOptimizeFrom(t *testing.T) {
+	eval := func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-3, 2) + math.Pow(p.Terms[1]-4, 2)
+	}
+	initial := []*Point{
+		{Dims: 2, Terms: []float64{3, 4}},
+		{Dims: 2, Terms: []float64{4, 4}},
+		{Dims: 2, Terms: []float64{3, 5}},
+	}
+	opts := DefaultOptions()
+	opts.MaxIters = 0
+	result, err := OptimizeFrom(eval, initial, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, initial[0], result.Best)
+	assert.Equal(t, 0.0, result.BestValue)
+}
+
+func TestOptimizeFromInvalid(t *testing.T) {
+	eval := func(p *Point) float64 { return 0 }
+
+	_, err := OptimizeFrom(eval, nil, nil)
+	assert.Equal(t, ErrNoPoints, err)
+
+	// Too few points to span two dimensions
+	_, err = OptimizeFrom(eval, []*Point{
+		{Dims: 2, Terms: []float64{0, 0}},
+		{Dims: 2, Terms: []float64{1, 1}},
+	}, nil)
+	assert.EqualError(t, err, `Simplex: got 2 points, expected 3 for 2 dimensions`)
+
+	_, err = OptimizeFrom(eval, []*Point{
+		{Dims: 2, Terms: []float64{0, 0}},
+		{Dims: 2, Terms: []float64{1, 1}},
+		{Dims: 1, Terms: []float64{1}},
+	}, nil)
+	assert.EqualError(t, err, `Simplex: point 2 has Dims 1, expected 2`)
+
+	// A single point is not a simplex, even in no dimensions
+	_, err = OptimizeFrom(eval, []*Point{NewPoint(0)}, nil)
+	assert.EqualError(t, err, `need at least 2 initial points, got 1`)
+}
+
+func TestOptimizeOneDimension(t *testing.T) {
+	eval := func(p *Point) float64 { return math.Pow(p.Terms[0]-5, 2) }
+	for seed := int64(1); seed <= 10; seed++ {
+		for _, strategy := range []InitStrategy{InitRandom, InitLatinHypercube} {
+			opts := seededOptions(seed)
+			opts.InitStrategy = strategy
+			opts.FTol = 1e-12
+			opts.MaxIters = 1000
+			result, err := OptimizeWithOptions(eval, 1, opts)
+			assert.NoError(t, err)
+			assert.True(t, result.Converged)
+			assert.Equal(t, 2, len(result.FinalSimplex.Points))
+			assert.InDelta(t, 5, result.Best.Terms[0], 1e-3)
+		}
+	}
+
+	// The centroid of every point but the worst is the best
+	// point, through which the worst point is reflected
+	s := NewSimplex(1)
+	s.SetPoint(&Point{Dims: 1, Terms: []float64{0}}, eval(&Point{Dims: 1, Terms: []float64{0}}))
+	s.SetPoint(&Point{Dims: 1, Terms: []float64{1}}, eval(&Point{Dims: 1, Terms: []float64{1}}))
+	assert.Equal(t, []float64{1}, s.CentroidExcludingWorst().Terms)
+	assert.Equal(t, OpExpand, s.Step(eval, DefaultOptions()))
+	assert.Equal(t, []float64{3}, s.Points[0].Terms)
+
+	_, err := OptimizeWithOptions(eval, 0, nil)
+	assert.EqualError(t, err, `need at least 1 dimension, got 0`)
+	assert.NotNil(t, panicMessage(func() { Optimize(eval, -1) }))
+}
+
+func TestSimplexValidate(t *testing.T) {
+	build := func() *Simplex {
+		s := NewSimplex(2)
+		s.SetPoint(&Point{Dims: 2, Terms: []float64{0, 0}}, 0)
+		s.SetPoint(&Point{Dims: 2, Terms: []float64{1, 0}}, 1)
+		s.SetPoint(&Point{Dims: 2, Terms: []float64{0, 1}}, 2)
+		return s
+	}
+	assert.NoError(t, build().Validate())
+
+	s := build()
+	s.Points = s.Points[:2]
+	assert.EqualError(t, s.Validate(), `Simplex: got 2 points, expected 3 for 2 dimensions`)
+
+	s = build()
+	s.Evaluations = s.Evaluations[:2]
+	assert.EqualError(t, s.Validate(), `Simplex: got 2 evaluations for 3 points`)
+
+	s = build()
+	s.Points[1] = nil
+	assert.EqualError(t, s.Validate(), `Simplex: point 1 is nil`)
+
+	s = build()
+	s.Points[1] = &Point{Dims: 3, Terms: []float64{1, 0, 0}}
+	assert.EqualError(t, s.Validate(), `Simplex: point 1 has Dims 3, expected 2`)
+
+	// A short point whose Dims disagrees with its terms
+	s = build()
+	s.Points[2] = &Point{Dims: 2, Terms: []float64{1}}
+	assert.EqualError(t, s.Validate(), `Simplex: point 2 has 1 terms, expected 2`)
+}
+
+func TestOptimizeWithoutTrace(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	dir, err := ioutil.TempDir(``, `simplex`)
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, os.Chdir(dir))
+	defer os.Chdir(wd)
+
+	eval := func(p *Point) float64 { return p.Terms[0] * p.Terms[0] }
+	_, err = OptimizeWithOptions(eval, 1, seededOptions(1))
+	assert.NoError(t, err)
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(files))
+}
+
+func TestOptimizeOperations(t *testing.T) {
+	// Starting far from the minimum of a quadratic, the first
+	// reflection moves downhill past the best point
+	eval := func(p *Point) float64 {
+		return p.Terms[0]*p.Terms[0] + p.Terms[1]*p.Terms[1]
+	}
+	initial := []*Point{
+		{Dims: 2, Terms: []float64{10, 10}},
+		{Dims: 2, Terms: []float64{11, 10}},
+		{Dims: 2, Terms: []float64{10, 11}},
+	}
+	opts := DefaultOptions()
+	opts.FTol = 0
+	opts.MaxIters = 50
+	result, err := OptimizeFrom(eval, initial, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, result.Iterations, len(result.Operations))
+	first := result.Operations[0]
+	assert.True(t, first == OpExpand || first == OpInitialReflect || first == OpReflect)
+
+	assert.Equal(t, `expand`, OpExpand.String())
+	assert.Equal(t, `Operation(9)`, Operation(9).String())
+}
+
+func TestOptimizeRecordPath(t *testing.T) {
+	eval := func(p *Point) float64 { return p.Dot(p) }
+	opts := seededOptions(1)
+	opts.MaxIters = 30
+	result, err := OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+	assert.Nil(t, result.Path)
+
+	opts = seededOptions(1)
+	opts.MaxIters = 30
+	opts.RecordPath = true
+	result, err = OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, result.Iterations, len(result.Path))
+	assert.Equal(t, result.Best, result.Path[len(result.Path)-1])
+	for i := 1; i < len(result.Path); i++ {
+		// The best point never gets worse, and every entry is
+		// its own copy
+		assert.True(t, eval(result.Path[i]) <= eval(result.Path[i-1]))
+		assert.True(t, result.Path[i] != result.Path[i-1])
+	}
+}
+
+func TestOptimizeRecordWorstValues(t *testing.T) {
+	eval := func(p *Point) float64 { return p.Dot(p) }
+	opts := seededOptions(1)
+	opts.MaxIters = 60
+	opts.RecordPath = true
+	result, err := OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, result.Iterations, len(result.BestValues))
+	assert.Equal(t, result.Iterations, len(result.WorstValues))
+	for i, p := range result.Path {
+		assert.Equal(t, eval(p), result.BestValues[i])
+		assert.True(t, result.BestValues[i] <= result.WorstValues[i])
+		// On a convex objective every operation replaces the
+		// worst point by a better one or shrinks the simplex
+		// toward the best point
+		if i > 0 {
+			assert.True(t, result.WorstValues[i] <= result.WorstValues[i-1])
+		}
+	}
+	last := len(result.WorstValues) - 1
+	assert.True(t, result.WorstValues[last]-result.BestValues[last] < result.WorstValues[0]-result.BestValues[0])
+
+	// Values are reported in the sign of the objective
+	opts = seededOptions(1)
+	opts.MaxIters = 60
+	opts.RecordPath = true
+	opts.Maximize = true
+	result, err = OptimizeWithOptions(func(p *Point) float64 { return -eval(p) }, 2, opts)
+	assert.NoError(t, err)
+	for i := range result.WorstValues {
+		assert.True(t, result.BestValues[i] >= result.WorstValues[i])
+	}
+}
+
+func TestOptimizeRecordCentroid(t *testing.T) {
+	eval := func(p *Point) float64 { return p.Dot(p) }
+	opts := seededOptions(1)
+	opts.MaxIters = 30
+	plain, err := OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+	assert.Nil(t, plain.CentroidValues)
+
+	var centroids []float64
+	opts = seededOptions(1)
+	opts.MaxIters = 30
+	opts.RecordCentroid = true
+	opts.Callback = func(iter int, s *Simplex) bool {
+		centroids = append(centroids, eval(s.Centroid()))
+		return true
+	}
+	result, err := OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, result.Iterations, len(result.CentroidValues))
+	assert.Equal(t, centroids, result.CentroidValues)
+	// The same steps are taken at the cost of one evaluation
+	// per iteration
+	assert.Equal(t, plain.Iterations, result.Iterations)
+	assert.Equal(t, plain.Evaluations+result.Iterations, result.Evaluations)
+
+	// Maximizing records the values in the sign of eval
+	opts.Maximize = true
+	opts.Callback = nil
+	result, err = OptimizeWithOptions(func(p *Point) float64 { return -eval(p) }, 2, opts)
+	assert.NoError(t, err)
+	for _, v := range result.CentroidValues {
+		assert.True(t, v <= 0)
+	}
+}
+
+func TestSimplexStep(t *testing.T) {
+	// The worst point (0, 1) reflects through the centroid
+	// (0.5, 0) of the others to (1, -1) and expands to
+	// (1.5, -2). It contracts inside to (0.25, 0.5), or outside
+	// to (0.75, -0.5) if the reflected point beats only the
+	// worst point. The values of those trial points select
+	// each operation in turn.
+	initial := map[string]float64{`[0 0]`: 0, `[1 0]`: 1, `[0 1]`: 2}
+	for _, tc := range []struct {
+		values   map[string]float64
+		op       Operation
+		expected [][]float64
+	}{
+		{map[string]float64{`[1 -1]`: 0.5},
+			OpReflect, [][]float64{{0, 0}, {1, -1}, {1, 0}}},
+		// A reflected point which ties the best is accepted
+		// after it rather than contracted
+		{map[string]float64{`[1 -1]`: 0},
+			OpReflect, [][]float64{{0, 0}, {1, -1}, {1, 0}}},
+		{map[string]float64{`[1 -1]`: -1, `[1.5 -2]`: -2},
+			OpExpand, [][]float64{{1.5, -2}, {0, 0}, {1, 0}}},
+		{map[string]float64{`[1 -1]`: -1, `[1.5 -2]`: 5},
+			OpInitialReflect, [][]float64{{1, -1}, {0, 0}, {1, 0}}},
+		{map[string]float64{`[0.25 0.5]`: 1.5},
+			OpContract, [][]float64{{0, 0}, {1, 0}, {0.25, 0.5}}},
+		{map[string]float64{`[1 -1]`: 2, `[0.25 0.5]`: 1.5},
+			OpContract, [][]float64{{0, 0}, {1, 0}, {0.25, 0.5}}},
+		{map[string]float64{`[1 -1]`: 1.5, `[0.75 -0.5]`: 1.2},
+			OpContractOutside, [][]float64{{0, 0}, {1, 0}, {0.75, -0.5}}},
+		{map[string]float64{`[1 -1]`: 1.5, `[0.75 -0.5]`: 1.5},
+			OpContractOutside, [][]float64{{0, 0}, {1, 0}, {0.75, -0.5}}},
+		// The outside contraction must be no worse than the
+		// reflected point, and the inside one is not tried
+		{map[string]float64{`[1 -1]`: 1.5, `[0.75 -0.5]`: 1.8, `[0.25 0.5]`: 0.5},
+			OpShrink, [][]float64{{0, 0}, {0.5, 0}, {0, 0.5}}},
+		{map[string]float64{},
+			OpShrink, [][]float64{{0, 0}, {0.5, 0}, {0, 0.5}}},
+	} {
+		eval := func(p *Point) float64 {
+			key := fmt.Sprint(p.Terms)
+			if v, ok := tc.values[key]; ok {
+				return v
+			}
+			if v, ok := initial[key]; ok {
+				return v
+			}
+			return 10
+		}
+		s := NewSimplex(2)
+		for _, terms := range [][]float64{{0, 0}, {1, 0}, {0, 1}} {
+			p := &Point{Dims: 2, Terms: terms}
+			s.SetPoint(p, eval(p))
+		}
+
+		assert.Equal(t, tc.op, s.Step(eval, nil))
+		assert.Equal(t, 1, s.Iterations)
+		for i, p := range s.Points {
+			assert.Equal(t, tc.expected[i], p.Terms)
+			assert.Equal(t, eval(p), s.Evaluations[i])
+		}
+	}
+
+	assert.Panics(t, func() { NewSimplex(2).Step(func(p *Point) float64 { return 0 }, nil) })
+}
+
+func TestShrinkSimplex(t *testing.T) {
+	eval := func(p *Point) float64 {
+		return p.Terms[0]*p.Terms[0] + p.Terms[1]*p.Terms[1]
+	}
+	s := NewSimplex(2)
+	for _, terms := range [][]float64{{1, 1}, {5, 1}, {1, -7}} {
+		p := &Point{Dims: 2, Terms: terms}
+		s.SetPoint(p, eval(p))
+	}
+	best := s.Points[0]
+
+	shrinkSimplex(s, eval, DefaultOptions())
+
+	// Every point except the best moves halfway towards it
+	assert.Equal(t, best, s.Points[0])
+	assert.Equal(t, []float64{3, 1}, s.Points[1].Terms)
+	assert.Equal(t, []float64{1, -3}, s.Points[2].Terms)
+	assert.Equal(t, []float64{2, 10, 10}, s.Evaluations)
+	for i, p := range s.Points {
+		assert.Equal(t, eval(p), s.Evaluations[i])
+	}
+}
+
+func TestShrinkSimplexResorts(t *testing.T) {
+	// After shrinking towards the best point, the former worst
+	// point becomes better than the second point
+	eval := func(p *Point) float64 { return math.Abs(p.Terms[0] - 4) }
+	s := NewSimplex(1)
+	s.SetPoint(&Point{Dims: 1, Terms: []float64{1}}, 3)
+	s.SetPoint(&Point{Dims: 1, Terms: []float64{9}}, 5)
+
+	shrinkSimplex(s, eval, DefaultOptions())
+	assert.Equal(t, []float64{5}, s.Points[0].Terms)
+	assert.Equal(t, []float64{1}, s.Points[1].Terms)
+	assert.Equal(t, []float64{1, 3}, s.Evaluations)
+}
+
+func TestOptimizeRejectedExpansionKeepsReflection(t *testing.T) {
+	// The reflected point lands on the minimum, so expanding
+	// beyond it is rejected and the reflected point is kept
+	eval := func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-1, 2) + math.Pow(p.Terms[1]+1, 2)
+	}
+	initial := []*Point{
+		{Dims: 2, Terms: []float64{0, 0}},
+		{Dims: 2, Terms: []float64{1, 0}},
+		{Dims: 2, Terms: []float64{0, 1}},
+	}
+	opts := DefaultOptions()
+	opts.MaxIters = 1
+	opts.FTol = 0
+	result, err := OptimizeFrom(eval, initial, opts)
+	assert.NoError(t, err)
+
+	s := result.FinalSimplex
+	for i, p := range s.Points {
+		assert.Equal(t, eval(p), s.Evaluations[i])
+	}
+	assert.Equal(t, 0.0, result.BestValue)
+}
+
+func TestSetPointSorted(t *testing.T) {
+	isSorted := func(s *Simplex) bool {
+		return sort.Float64sAreSorted(s.Evaluations)
+	}
+	s := NewSimplex(3)
+	values := []float64{5, 1, 9, 3}
+	points := make([]*Point, len(values))
+	for i, v := range values {
+		points[i] = &Point{Dims: 3, Terms: []float64{v, v, v}}
+		s.SetPoint(points[i], v)
+		assert.True(t, isSorted(s))
+		assert.Equal(t, i+1, len(s.Points))
+	}
+	assert.Equal(t, []float64{1, 3, 5, 9}, s.Evaluations)
+	assert.Equal(t, []*Point{points[1], points[3], points[0], points[2]}, s.Points)
+
+	// Once full, new points replace the worst point
+	p := &Point{Dims: 3, Terms: []float64{2, 2, 2}}
+	s.SetPoint(p, 2)
+	assert.True(t, isSorted(s))
+	assert.Equal(t, []float64{1, 2, 3, 5}, s.Evaluations)
+	assert.Equal(t, []*Point{points[1], p, points[3], points[0]}, s.Points)
+
+	p = &Point{Dims: 3, Terms: []float64{7, 7, 7}}
+	s.SetPoint(p, 7)
+	assert.Equal(t, []float64{1, 2, 3, 7}, s.Evaluations)
+	assert.Equal(t, p, s.Points[3])
+}
+
+func TestSimplexTieBreaking(t *testing.T) {
+	point := func(x float64) *Point { return &Point{Dims: 1, Terms: []float64{x}} }
+	s := NewSimplex(3)
+	for _, x := range []float64{1, 2, 3, 4} {
+		s.SetPoint(point(x), 5)
+	}
+	order := func() []float64 {
+		xs := make([]float64, len(s.Points))
+		for i, p := range s.Points {
+			xs[i] = p.Terms[0]
+		}
+		return xs
+	}
+	// Equal values keep the order in which they were added
+	assert.Equal(t, []float64{1, 2, 3, 4}, order())
+
+	// A new point equal to the others goes after them, so it
+	// cannot displace the worst point
+	assert.Equal(t, ErrWorseThanAll, s.TryImprove(point(5), 5))
+	s.SetPoint(point(6), 2)
+	s.SetPoint(point(7), 2)
+	assert.Equal(t, []float64{6, 7, 1, 2}, order())
+	assert.NoError(t, s.TryImprove(point(8), 2))
+	assert.Equal(t, []float64{6, 7, 8, 1}, order())
+
+	// Sorting again leaves the order of ties alone
+	s.Sort()
+	assert.Equal(t, []float64{6, 7, 8, 1}, order())
+
+	// Shrinking onto points of the same value as the best keeps
+	// the original order
+	shrinkSimplex(s, func(p *Point) float64 { return 2 }, DefaultOptions())
+	assert.Equal(t, []float64{6, 6.5, 7, 3.5}, order())
+}
+
+func TestOptimizeParallel(t *testing.T) {
+	eval := func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-3, 2) + math.Pow(p.Terms[1]-4, 2)
+	}
+	serial, err := OptimizeWithOptions(eval, 2, seededOptions(1))
+	assert.NoError(t, err)
+
+	// Evaluating concurrently takes the same steps
+	opts := seededOptions(1)
+	opts.Parallel = true
+	parallel, err := OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, serial.Best, parallel.Best)
+	assert.Equal(t, serial.BestValue, parallel.BestValue)
+	assert.Equal(t, serial.Iterations, parallel.Iterations)
+}
+
+func benchmarkShrink(b *testing.B, parallel bool) {
+	// A deliberately slow objective
+	eval := func(p *Point) float64 {
+		time.Sleep(100 * time.Microsecond)
+		return p.Terms[0]
+	}
+	opts := DefaultOptions()
+	opts.Parallel = parallel
+	base := NewPoint(8)
+	for i := 0; i < b.N; i++ {
+		s := NewSimplex(8)
+		for _, p := range SimplexAround(base, 1) {
+			s.SetPoint(p, p.Terms[0])
+		}
+		shrinkSimplex(s, eval, opts)
+	}
+}
+
+func BenchmarkShrinkSerial(b *testing.B) { benchmarkShrink(b, false) }
+
+func BenchmarkShrinkParallel(b *testing.B) { benchmarkShrink(b, true) }
+
+// BenchmarkSimplexRestart fills a simplex from scratch many times,
+// either allocating a new one each time or reusing one with Reset
+func BenchmarkSimplexRestart(b *testing.B) {
+	for _, dims := range benchmarkDims {
+		points := benchmarkPoints(dims, dims+1)
+		b.Run(fmt.Sprintf(`new/dims=%d`, dims), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				s := NewSimplex(dims)
+				for j, p := range points {
+					s.SetPoint(p, float64(j))
+				}
+			}
+		})
+		b.Run(fmt.Sprintf(`reset/dims=%d`, dims), func(b *testing.B) {
+			s := NewSimplex(dims)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				s.Reset()
+				for j, p := range points {
+					s.SetPoint(p, float64(j))
+				}
+			}
+		})
+	}
+}
+
+func TestOptimizeRestartOnStall(t *testing.T) {
+	// A narrow valley along y = 4
+	eval := func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-3, 2) + 100*math.Pow(p.Terms[1]-4, 2)
+	}
+	// A collinear simplex can never leave the line y = 0, so
+	// it stalls at (3, 0)
+	initial := func() []*Point {
+		return []*Point{
+			{Dims: 2, Terms: []float64{0, 0}},
+			{Dims: 2, Terms: []float64{1, 0}},
+			{Dims: 2, Terms: []float64{2, 0}},
+		}
+	}
+	opts := DefaultOptions()
+	opts.FTol = 0
+	opts.MaxIters = 500
+	stuck, err := OptimizeFrom(eval, initial(), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, stuck.Restarts)
+	assert.Equal(t, 0.0, stuck.Best.Terms[1])
+
+	opts.RestartOnStall = 10
+	opts.StallTol = 1e-9
+	restarted, err := OptimizeFrom(eval, initial(), opts)
+	assert.NoError(t, err)
+	assert.True(t, restarted.Restarts > 0)
+	assert.InDelta(t, 3, restarted.Best.Terms[0], 1e-3)
+	assert.InDelta(t, 4, restarted.Best.Terms[1], 1e-3)
+}
+
+// benchmarkDims are the dimensions covered by the benchmarks of
+// the core operations
+var benchmarkDims = []int{2, 10, 50}
+
+// benchmarkPoints returns count deterministic points of the
+// given dimension
+func benchmarkPoints(dims, count int) []*Point {
+	points := make([]*Point, count)
+	for i := range points {
+		points[i] = NewPoint(dims)
+		for d := range points[i].Terms {
+			points[i].Terms[d] = float64(i*dims + d)
+		}
+	}
+	return points
+}
+
+func BenchmarkSumPoints(b *testing.B) {
+	for _, dims := range benchmarkDims {
+		b.Run(fmt.Sprintf(`dims=%d`, dims), func(b *testing.B) {
+			points := benchmarkPoints(dims, dims+1)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				SumPoints(points...)
+			}
+		})
+	}
+}
+
+func BenchmarkComputeCentroid(b *testing.B) {
+	for _, dims := range benchmarkDims {
+		b.Run(fmt.Sprintf(`dims=%d`, dims), func(b *testing.B) {
+			points := benchmarkPoints(dims, dims+1)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				ComputeCentroid(points...)
+			}
+		})
+	}
+}
+
+func BenchmarkOptimizeSphere(b *testing.B) {
+	sphere := func(p *Point) float64 {
+		sum := 0.0
+		for _, v := range p.Terms {
+			sum += v * v
+		}
+		return sum
+	}
+	for _, dims := range benchmarkDims {
+		b.Run(fmt.Sprintf(`dims=%d`, dims), func(b *testing.B) {
+			// A fixed starting simplex and iteration count make
+			// every run take the same steps
+			base := NewPoint(dims)
+			for d := range base.Terms {
+				base.Terms[d] = 1
+			}
+			opts := DefaultOptions()
+			opts.FTol = 0
+			opts.MaxIters = 200
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := OptimizeFrom(sphere, SimplexAround(base, 1), opts); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,484 @@
+package simplex
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+)
+
+const (
+	defaultReflectCoeff  = 1
+	defaultExpandCoeff   = 2
+	defaultContractCoeff = 0.5
+	defaultShrinkCoeff   = 0.5
+	defaultSpread        = 10
+	defaultFTol          = 0.01
+	defaultMaxIters      = 10
+	defaultRestartStep   = 1
+	defaultCacheDigits   = 9
+	defaultInitFraction  = 0.1
+)
+
+// Coefficients holds the coefficients used by the Nelder-Mead
+// operations
+type Coefficients struct {
+	// Reflect scales the distance of a reflected point
+	// from the centroid
+	Reflect float64
+	// Expand scales the distance of an expanded point from
+	// the centroid relative to the reflected point
+	Expand float64
+	// Contract scales the distance of a contracted point
+	// from the centroid relative to the worst point
+	Contract float64
+	// Shrink scales the distance of every point from the
+	// best point when the simplex is shrunk
+	Shrink float64
+}
+
+// AdaptiveCoefficients returns the coefficients of Gao and
+// Han's adaptive Nelder-Mead method for an n dimensional
+// problem, which converge faster than the standard ones in
+// high dimensions. They coincide with the standard
+// coefficients for n = 2, and the standard coefficients are
+// returned for n < 2, where the adaptive shrink coefficient
+// would be zero.
+func AdaptiveCoefficients(n int) Coefficients {
+	if n < 2 {
+		return DefaultOptions().Coefficients
+	}
+	dims := float64(n)
+	return Coefficients{
+		Reflect:  1,
+		Expand:   1 + 2/dims,
+		Contract: 0.75 - 1/(2*dims),
+		Shrink:   1 - 1/dims,
+	}
+}
+
+// Options holds the coefficients used by the Nelder-Mead
+// operations performed during optimization along with the
+// settings used to generate the initial simplex
+type Options struct {
+	Coefficients
+	// Adaptive replaces Coefficients with
+	// AdaptiveCoefficients for the problem's dimension
+	Adaptive bool
+	// CentroidMethod selects how the center of reflection is
+	// computed from every point but the worst. Defaults to
+	// CentroidMean. CentroidMedian keeps the center near the
+	// bulk of the points when one of them is far away, which
+	// can steady the reflections when a vertex is wildly bad.
+	// However its coordinates are taken from the points
+	// themselves, so in three or more dimensions the simplex
+	// tends to flatten and may stall short of the optimum; it
+	// also costs a sort per dimension. The two agree in one
+	// and two dimensions, where the center is computed from
+	// at most two points. CentroidCompensatedMean is the mean
+	// with less rounding error, for problems with many
+	// dimensions or coordinates far larger than the simplex.
+	CentroidMethod CentroidMethod
+	// ReflectionCenter selects the point through which the
+	// worst point is reflected. Defaults to
+	// ReflectThroughCentroid, the standard method, for which
+	// CentroidMethod is used. The other strategies are meant
+	// for experiments: the convergence results of Nelder-Mead
+	// do not carry over to them and they may stall or fail to
+	// converge on some problems.
+	ReflectionCenter ReflectionCenter
+
+	// Rand is the source of randomness for the initial
+	// simplex. If nil, a time-seeded source is used unless
+	// Deterministic is set.
+	Rand *rand.Rand
+	// Deterministic guarantees that runs with the same inputs
+	// evaluate the objective at bit-for-bit identical points
+	// in the same order. The only sources of nondeterminism
+	// are the time-seeded source used when Rand is nil, which
+	// is replaced by a fixed seed, and the scheduling of
+	// concurrent evaluations, so Parallel may not be set.
+	// A Rand shared with other code, or a Callback or
+	// objective which are themselves nondeterministic, still
+	// make runs differ.
+	Deterministic bool
+	// Spread is the width of the range [0, Spread) from
+	// which initial point coordinates are drawn
+	Spread float64
+	// InitStrategy selects how the points of the initial
+	// simplex are spread over their range. Defaults to
+	// InitRandom.
+	InitStrategy InitStrategy
+	// InitBox, if set, is a box with InitBox[d] holding the
+	// lower and upper limit of dimension d within which the
+	// optimum is expected. The initial simplex is then placed
+	// at a random position inside the box, with one edge along
+	// each axis InitFraction of that dimension's width long,
+	// instead of being drawn according to InitStrategy. If
+	// Bounds are set the box is first cut down to the part
+	// inside them, which must not be empty.
+	InitBox [][2]float64
+	// InitFraction is the fraction of the width of each
+	// dimension of InitBox, once cut down to Bounds, spanned
+	// by the initial simplex. Defaults to 0.1.
+	InitFraction float64
+	// InitialStep, if positive, sets the size of the random
+	// initial simplex: one point is drawn uniformly at random
+	// and each of the others is offset from it by InitialStep
+	// along one axis, as by SimplexAround. The first point is
+	// drawn from Bounds if they are set, or from [0, Spread)
+	// otherwise, leaving room for the offsets where the range
+	// is wide enough. Like Spread it is measured in the scaled
+	// coordinates, so the offset along dimension d is
+	// InitialStep times Scale[d]. Points which still fall
+	// outside Bounds are brought back inside according to
+	// BoundaryMode, which shortens their edges. It can only be
+	// combined with the default InitRandom strategy, and not
+	// with InitBox.
+	InitialStep float64
+
+	// Scale, if set, holds a positive factor for each
+	// dimension by which the optimizer divides the coordinates
+	// of every point, so that it works with dimensions of
+	// similar size. The objective, Bounds, initial points,
+	// Callback, TraceWriter, HistoryWriter and the Result all
+	// use the original coordinates, while Spread, XTol and
+	// RestartStep are measured in the scaled coordinates, in
+	// which a unit step along dimension d is a step of
+	// Scale[d] in the original ones.
+	Scale []float64
+
+	// MultiStart selects how the runs of OptimizeMultiStart
+	// relate to each other. Defaults to MultiStartIndependent.
+	MultiStart MultiStartMode
+
+	// IntegerDims lists dimensions whose coordinates must be
+	// integers. The simplex itself moves continuously, but the
+	// objective is always evaluated with these coordinates
+	// rounded to the nearest integer, and Result.Best and
+	// Result.Path hold the rounded points. This is a heuristic
+	// which works best when the objective varies slowly from
+	// one integer to the next; it is not exact integer
+	// programming and may miss the integer optimum. The
+	// Callback, traces and Result.FinalSimplex see the
+	// unrounded points.
+	IntegerDims []int
+
+	// Maximize searches for the maximum of the objective
+	// rather than the minimum
+	Maximize bool
+
+	// Bounds optionally restricts the search to a box, with
+	// Bounds[d] holding the lower and upper limit of dimension
+	// d. Every trial point is brought back inside the box
+	// according to BoundaryMode before it is evaluated, and
+	// the initial simplex is drawn from the box instead of
+	// from [0, Spread).
+	Bounds [][2]float64
+	// BoundaryMode selects whether out of bounds points are
+	// clamped to or reflected off of the bounds. Defaults to
+	// BoundaryClamp.
+	BoundaryMode BoundaryMode
+
+	// Equality, if set, keeps the search on the affine
+	// subspace of points satisfying a set of linear equations.
+	// The initial points and every trial point are projected
+	// onto the subspace before they are evaluated. Only linear
+	// equalities are supported. When Bounds are also set the
+	// projection is applied first, so clamping or reflecting
+	// a point back inside the bounds can move it off of the
+	// subspace.
+	Equality *LinearEquality
+
+	// FTol stops the optimization once the standard deviation
+	// of the simplex's values falls below it
+	FTol float64
+	// NormalizeFTol measures FTol relative to the initial
+	// spread of the values: the standard deviation of the
+	// simplex's values is divided by the standard deviation of
+	// the values of the initial simplex before it is compared
+	// with FTol, so that multiplying the objective by a
+	// constant does not change when the optimization stops.
+	// If the initial values are all equal or not all finite,
+	// the first later simplex whose values have a positive,
+	// finite standard deviation is used instead, and until
+	// then FTol is compared with the raw standard deviation.
+	// Only the FTol and Patience checks are affected; the
+	// values seen by FTolRel, TargetValue, TerminateFunc, the
+	// Callback and the Result are not normalized.
+	NormalizeFTol bool
+	// FTolRel stops the optimization once the standard
+	// deviation of the simplex's values falls below FTolRel
+	// times the magnitude of the best value, which behaves the
+	// same however the objective is scaled. It is never met
+	// while the best value is 0, so pair it with FTol for
+	// objectives whose optimum may be 0.
+	FTolRel float64
+	// Patience, if positive, stops the optimization as
+	// converged once Patience consecutive iterations have gone
+	// by without the best value improving by more than FTol
+	// on the best value at the start of them. The improvement
+	// is normalized like the standard deviation when
+	// NormalizeFTol is set. It is checked after the
+	// tolerances, so the optimization stops as soon as either
+	// is met.
+	Patience int
+	// XTol stops the optimization once the largest distance
+	// between two points of the simplex falls below it
+	//
+	// Optimization stops as soon as any tolerance is met,
+	// and a tolerance of 0 disables its check. On flat regions
+	// the values can agree long before the points do, so set
+	// FTol to 0 to rely on XTol alone for such objectives.
+	XTol float64
+	// TargetValue, if set, stops the optimization as converged
+	// once the best value is at most *TargetValue, or at least
+	// *TargetValue when Maximize is set. It is checked after
+	// the tolerances and before TerminateFunc.
+	TargetValue *float64
+	// TerminateFunc, if set, is a custom stopping rule checked
+	// alongside the tolerances, before every iteration, with
+	// the current simplex and the number of iterations
+	// performed so far. Returning true stops the optimization
+	// as converged. It is consulted after the tolerances and
+	// before MaxIters and MaxEvaluations, so it takes
+	// precedence over them when both would stop the same
+	// iteration. Set FTol and XTol to 0 to rely on it alone.
+	// The simplex is in the form passed to Callback and must be
+	// treated as read only.
+	TerminateFunc func(s *Simplex, iter int) bool
+	// MaxIters is the largest number of iterations performed
+	// before giving up on convergence
+	MaxIters int
+	// MaxEvaluations, if positive, is the largest number of
+	// times the objective is evaluated. Optimization stops,
+	// partway through an iteration if need be, before any
+	// evaluation which would exceed it. The initial simplex is
+	// always evaluated in full.
+	MaxEvaluations int
+
+	// Callback, if set, is called after every iteration with
+	// the number of iterations performed so far and the
+	// current simplex. The simplex must be treated as read
+	// only; use Clone to keep a snapshot of it. Returning
+	// false stops the optimization.
+	Callback func(iter int, s *Simplex) bool
+	// TraceWriter, if set, receives the simplex at every
+	// iteration in the format written by writeSimplex. No
+	// trace is written when it is nil.
+	TraceWriter io.Writer
+	// HistoryWriter, if set, receives a CSV table with one row
+	// per iteration holding the iteration number, the best and
+	// worst costs and the standard deviation of the costs. If
+	// HistoryPoints is set the coordinates of the best point
+	// are appended to each row.
+	HistoryWriter io.Writer
+	HistoryPoints bool
+	// RecordPath keeps a copy of the best point after every
+	// iteration in Result.Path, and the best and worst values
+	// of the simplex in Result.BestValues and
+	// Result.WorstValues
+	RecordPath bool
+	// RecordCentroid evaluates the objective at the centroid
+	// of all of the points of the simplex after every
+	// iteration and keeps the values in Result.CentroidValues.
+	// This is a diagnostic which costs one evaluation per
+	// iteration.
+	RecordCentroid bool
+	// GradientStep, if positive, estimates the gradient of the
+	// objective at the best point once the optimization stops
+	// and keeps it in Result.Gradient, to check that the point
+	// is close to stationary. The slopes are central
+	// differences with this step, measured in the scaled
+	// coordinates like InitialStep, and become one-sided where
+	// a step would leave Bounds. This costs up to two
+	// evaluations per dimension, which are skipped if they
+	// would exceed MaxEvaluations.
+	GradientStep float64
+	// Logger, if set, receives messages about the optimization
+	// at LogLevel and below. Nothing is logged when it is nil.
+	Logger   *log.Logger
+	LogLevel LogLevel
+
+	// Parallel evaluates independent trial points
+	// concurrently: the initial points, the points of a
+	// shrunk simplex, and the expanded point, which is
+	// evaluated speculatively alongside the reflected point.
+	// The objective must be safe for concurrent use when
+	// this is set.
+	Parallel bool
+
+	// Cache memoizes the objective with a CachedObjective so
+	// that points whose terms agree when rounded to
+	// CacheDigits decimal digits are evaluated only once
+	Cache       bool
+	CacheDigits int
+
+	// RepairDuplicates moves one of any two vertices closer
+	// together than XTol after each iteration, unless the
+	// whole simplex is, so that a simplex which has collapsed
+	// onto fewer dimensions regains them. The worse vertex is
+	// replaced by the better one moved 10 XTol along the axis
+	// on which the simplex is narrowest, at the cost of one
+	// evaluation. XTol must be positive.
+	RepairDuplicates bool
+
+	// MinEdge, if positive, is a floor on the length of the
+	// edges of the simplex. A shrink which would take an edge
+	// below it shrinks less, so that the shortest edge ends up
+	// at MinEdge, and once a shrink reaches the floor the
+	// optimization stops as converged, or restarts around the
+	// best point with RestartStep if RestartAtMinEdge is set.
+	// Like XTol it is measured in the scaled coordinates.
+	MinEdge          float64
+	RestartAtMinEdge bool
+	// RestartOnNoProgress rebuilds the simplex around its best
+	// point with RestartStep when an iteration leaves it
+	// unchanged, instead of stopping with StopNoProgress
+	RestartOnNoProgress bool
+
+	// RestartOnStall, if positive, is the number of
+	// consecutive iterations without the best value improving
+	// by more than StallTol after which the simplex is rebuilt
+	// around its best point with SimplexAround and RestartStep
+	RestartOnStall int
+	// RestartStep is the step used to rebuild a stalled
+	// simplex, one which reached MinEdge or one which made no
+	// progress
+	RestartStep float64
+	// StallTol is the improvement in the best value below
+	// which an iteration counts as stalled
+	StallTol float64
+
+	// events receives an IterationEvent after every iteration
+	// when set by OptimizeStream
+	events chan<- IterationEvent
+	// abort, if set, is checked between iterations and stops
+	// the optimization with StopFailures when it returns true
+	abort func() bool
+}
+
+// DefaultOptions returns the standard Nelder-Mead coefficients
+func DefaultOptions() *Options {
+	return &Options{
+		Coefficients: Coefficients{
+			Reflect:  defaultReflectCoeff,
+			Expand:   defaultExpandCoeff,
+			Contract: defaultContractCoeff,
+			Shrink:   defaultShrinkCoeff,
+		},
+		Spread:       defaultSpread,
+		FTol:         defaultFTol,
+		MaxIters:     defaultMaxIters,
+		RestartStep:  defaultRestartStep,
+		CacheDigits:  defaultCacheDigits,
+		InitFraction: defaultInitFraction,
+	}
+}
+
+// Validate returns an error if any of the coefficients are
+// outside of the range required by the algorithm
+func (o *Options) Validate() error {
+	if o.Reflect <= 0 {
+		return fmt.Errorf(`Options: Reflect must be positive, got %v`, o.Reflect)
+	}
+	if o.Expand <= 1 {
+		return fmt.Errorf(`Options: Expand must be greater than 1, got %v`, o.Expand)
+	}
+	if o.Contract <= 0 || o.Contract >= 1 {
+		return fmt.Errorf(`Options: Contract must be in (0, 1), got %v`, o.Contract)
+	}
+	if o.Shrink <= 0 || o.Shrink >= 1 {
+		return fmt.Errorf(`Options: Shrink must be in (0, 1), got %v`, o.Shrink)
+	}
+	if o.Spread <= 0 {
+		return fmt.Errorf(`Options: Spread must be positive, got %v`, o.Spread)
+	}
+	if o.FTol < 0 {
+		return fmt.Errorf(`Options: FTol must not be negative, got %v`, o.FTol)
+	}
+	if o.FTolRel < 0 {
+		return fmt.Errorf(`Options: FTolRel must not be negative, got %v`, o.FTolRel)
+	}
+	if o.XTol < 0 {
+		return fmt.Errorf(`Options: XTol must not be negative, got %v`, o.XTol)
+	}
+	if o.MaxIters < 0 {
+		return fmt.Errorf(`Options: MaxIters must not be negative, got %v`, o.MaxIters)
+	}
+	if o.MaxEvaluations < 0 {
+		return fmt.Errorf(`Options: MaxEvaluations must not be negative, got %v`, o.MaxEvaluations)
+	}
+	if o.Patience < 0 {
+		return fmt.Errorf(`Options: Patience must not be negative, got %v`, o.Patience)
+	}
+	if o.RestartOnStall < 0 {
+		return fmt.Errorf(`Options: RestartOnStall must not be negative, got %v`, o.RestartOnStall)
+	}
+	if o.RestartOnStall > 0 && o.RestartStep == 0 {
+		return fmt.Errorf(`Options: RestartStep must be nonzero to restart on stall`)
+	}
+	if o.RepairDuplicates && o.XTol == 0 {
+		return fmt.Errorf(`Options: XTol must be positive to repair duplicates`)
+	}
+	if o.MinEdge < 0 {
+		return fmt.Errorf(`Options: MinEdge must not be negative, got %v`, o.MinEdge)
+	}
+	if o.RestartAtMinEdge && o.RestartStep == 0 {
+		return fmt.Errorf(`Options: RestartStep must be nonzero to restart at MinEdge`)
+	}
+	if o.RestartOnNoProgress && o.RestartStep == 0 {
+		return fmt.Errorf(`Options: RestartStep must be nonzero to restart on no progress`)
+	}
+	if o.StallTol < 0 {
+		return fmt.Errorf(`Options: StallTol must not be negative, got %v`, o.StallTol)
+	}
+	if o.Deterministic && o.Parallel {
+		return fmt.Errorf(`Options: Parallel evaluation is not deterministic`)
+	}
+	if o.CacheDigits < 0 {
+		return fmt.Errorf(`Options: CacheDigits must not be negative, got %v`, o.CacheDigits)
+	}
+	for d, f := range o.Scale {
+		if f <= 0 {
+			return fmt.Errorf(`Options: Scale for dimension %d must be positive, got %v`, d, f)
+		}
+	}
+	if o.InitStrategy < InitRandom || o.InitStrategy > InitGrid {
+		return fmt.Errorf(`Options: unknown %v`, o.InitStrategy)
+	}
+	if err := validateInitBox(o.InitBox); err != nil {
+		return err
+	}
+	if o.InitialStep < 0 {
+		return fmt.Errorf(`Options: InitialStep must not be negative, got %v`, o.InitialStep)
+	}
+	if o.GradientStep < 0 {
+		return fmt.Errorf(`Options: GradientStep must not be negative, got %v`, o.GradientStep)
+	}
+	if o.InitialStep > 0 && o.InitBox != nil {
+		return fmt.Errorf(`Options: InitialStep and InitBox cannot both be set`)
+	}
+	if o.InitialStep > 0 && o.InitStrategy != InitRandom {
+		return fmt.Errorf(`Options: InitialStep cannot be combined with the %v strategy`, o.InitStrategy)
+	}
+	if o.InitBox != nil && (o.InitFraction <= 0 || o.InitFraction > 1) {
+		return fmt.Errorf(`Options: InitFraction must be in (0, 1], got %v`, o.InitFraction)
+	}
+	if o.CentroidMethod < CentroidMean || o.CentroidMethod > CentroidCompensatedMean {
+		return fmt.Errorf(`Options: unknown %v`, o.CentroidMethod)
+	}
+	if o.ReflectionCenter < ReflectThroughCentroid || o.ReflectionCenter > ReflectThroughBest {
+		return fmt.Errorf(`Options: unknown %v`, o.ReflectionCenter)
+	}
+	if o.MultiStart != MultiStartIndependent && o.MultiStart != MultiStartSeeded {
+		return fmt.Errorf(`Options: unknown %v`, o.MultiStart)
+	}
+	if o.LogLevel < LogInfo || o.LogLevel > LogDebug {
+		return fmt.Errorf(`Options: unknown %v`, o.LogLevel)
+	}
+	if o.BoundaryMode != BoundaryClamp && o.BoundaryMode != BoundaryReflect {
+		return fmt.Errorf(`Options: unknown %v`, o.BoundaryMode)
+	}
+	return validateBounds(o.Bounds)
+}
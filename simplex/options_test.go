@@ -0,0 +1,153 @@
+package simplex
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+func TestDefaultOptionsValid(t *testing.T) {
+	assert.NoError(t, DefaultOptions().Validate())
+}
+
+func TestOptionsValidate(t *testing.T) {
+	cases := []func(o *Options){
+		func(o *Options) { o.Reflect = 0 },
+		func(o *Options) { o.Expand = 1 },
+		func(o *Options) { o.Contract = 0 },
+		func(o *Options) { o.Contract = 1 },
+		func(o *Options) { o.Shrink = 0 },
+		func(o *Options) { o.Shrink = 1.5 },
+		func(o *Options) { o.Spread = 0 },
+		func(o *Options) { o.FTol = -1 },
+		func(o *Options) { o.XTol = -1 },
+		func(o *Options) { o.MaxIters = -1 },
+		func(o *Options) { o.MaxEvaluations = -1 },
+		func(o *Options) { o.RestartOnStall = -1 },
+		func(o *Options) { o.RestartOnStall, o.RestartStep = 5, 0 },
+		func(o *Options) { o.StallTol = -1 },
+		func(o *Options) { o.CacheDigits = -1 },
+		func(o *Options) { o.Deterministic, o.Parallel = true, true },
+		func(o *Options) { o.InitStrategy = InitGrid + 1 },
+	}
+	for _, c := range cases {
+		opts := DefaultOptions()
+		c(opts)
+		assert.Error(t, opts.Validate())
+	}
+}
+
+func TestOptimizeWithInvalidOptions(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Expand = 0.5
+	result, err := OptimizeWithOptions(func(p *Point) float64 { return 0 }, 2, opts)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestInitPointsSeeded(t *testing.T) {
+	first := initPoints(rand.New(rand.NewSource(42)), 3, 4, 10)
+	second := initPoints(rand.New(rand.NewSource(42)), 3, 4, 10)
+	assert.Equal(t, first, second)
+
+	for _, p := range initPoints(rand.New(rand.NewSource(7)), 3, 4, 0.5) {
+		for _, v := range p.Terms {
+			assert.True(t, v >= 0 && v < 0.5)
+		}
+	}
+}
+
+func TestOptimizeSeeded(t *testing.T) {
+	eval := func(p *Point) float64 {
+		return math.Pow(p.Terms[1]-3, 2) + math.Pow(p.Terms[0]-4, 2)
+	}
+	run := func() (*Point, float64) {
+		result, err := OptimizeWithOptions(eval, 2, seededOptions(1))
+		assert.NoError(t, err)
+		return result.Best, result.BestValue
+	}
+	best1, cost1 := run()
+	best2, cost2 := run()
+	assert.Equal(t, best1, best2)
+	assert.Equal(t, cost1, cost2)
+}
+
+func TestAdaptiveCoefficients(t *testing.T) {
+	assert.Equal(t, DefaultOptions().Coefficients, AdaptiveCoefficients(2))
+	assert.Equal(t, DefaultOptions().Coefficients, AdaptiveCoefficients(1))
+	assert.Equal(t, Coefficients{
+		Reflect:  1,
+		Expand:   1.2,
+		Contract: 0.7,
+		Shrink:   0.9,
+	}, AdaptiveCoefficients(10))
+}
+
+func TestOptimizeAdaptive(t *testing.T) {
+	sphere := func(p *Point) float64 {
+		sum := 0.0
+		for _, v := range p.Terms {
+			sum += v * v
+		}
+		return sum
+	}
+	base := NewPoint(20)
+	for d := range base.Terms {
+		base.Terms[d] = 1
+	}
+	run := func(adaptive bool) *Result {
+		opts := DefaultOptions()
+		opts.Adaptive = adaptive
+		opts.FTol = 0
+		opts.XTol = 1e-6
+		opts.MaxIters = 20000
+		result, err := OptimizeFrom(sphere, SimplexAround(base, 1), opts)
+		assert.NoError(t, err)
+		return result
+	}
+	standard := run(false)
+	adaptive := run(true)
+	t.Logf(`standard: %d iterations, value %v; adaptive: %d iterations, value %v`,
+		standard.Iterations, standard.BestValue, adaptive.Iterations, adaptive.BestValue)
+
+	assert.Equal(t, AdaptiveCoefficients(20), adaptive.Coefficients)
+	assert.Equal(t, DefaultOptions().Coefficients, standard.Coefficients)
+	assert.True(t, adaptive.Converged)
+	assert.True(t, adaptive.Iterations < standard.Iterations)
+}
+
+func TestOptimizeDeterministic(t *testing.T) {
+	// record returns an objective which appends every point it
+	// is evaluated at to evaluated
+	record := func(evaluated *[][]float64) func(p *Point) float64 {
+		return func(p *Point) float64 {
+			*evaluated = append(*evaluated, append([]float64(nil), p.Terms...))
+			x, y := p.Terms[0], p.Terms[1]
+			return math.Pow(1-x, 2) + 100*math.Pow(y-x*x, 2)
+		}
+	}
+	opts := DefaultOptions()
+	opts.Deterministic = true
+	opts.FTol = 0
+	opts.MaxIters = 50
+
+	var first, second [][]float64
+	initial := SimplexAround(&Point{Dims: 2, Terms: []float64{-1.2, 1}}, 0.5)
+	_, err := OptimizeFrom(record(&first), initial, opts)
+	assert.NoError(t, err)
+	initial = SimplexAround(&Point{Dims: 2, Terms: []float64{-1.2, 1}}, 0.5)
+	_, err = OptimizeFrom(record(&second), initial, opts)
+	assert.NoError(t, err)
+	assert.True(t, len(first) > 50)
+	assert.Equal(t, first, second)
+
+	// Random initial simplexes are drawn from a fixed seed
+	first, second = nil, nil
+	_, err = OptimizeWithOptions(record(&first), 2, opts)
+	assert.NoError(t, err)
+	_, err = OptimizeWithOptions(record(&second), 2, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+}
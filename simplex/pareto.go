@@ -0,0 +1,93 @@
+package simplex
+
+import "fmt"
+
+// Weighted returns an objective which is the sum of objectives
+// weighted by the corresponding weights. Minimizing it for
+// positive weights finds a point which no other point
+// improves in every objective at once.
+func Weighted(objectives []func(p *Point) float64, weights []float64) (func(p *Point) float64, error) {
+	if len(objectives) != len(weights) {
+		return nil, fmt.Errorf(`Weighted: got %d weights for %d objectives`,
+			len(weights), len(objectives))
+	}
+	return func(p *Point) float64 {
+		sum := 0.0
+		for i, obj := range objectives {
+			sum += weights[i] * obj(p)
+		}
+		return sum
+	}, nil
+}
+
+// ParetoPoint is the optimum of one weighting of several
+// objectives
+type ParetoPoint struct {
+	// Weights are the weights the objectives were combined with
+	Weights []float64
+	// Point is the optimum of the weighted objective
+	Point *Point
+	// Values holds the value of each objective at Point
+	Values []float64
+}
+
+// ParetoFront approximates the Pareto front of objectives by
+// optimizing their Weighted combination with each of weights in
+// turn using OptimizeWithOptions. Optima which another optimum
+// is at least as good as in every objective, and better than in
+// one, are dropped; the rest are returned in the order of their
+// weights. Only the convex parts of the front can be found
+// this way.
+func ParetoFront(objectives []func(p *Point) float64, weights [][]float64, dims int, opts *Options) ([]ParetoPoint, error) {
+	maximize := opts != nil && opts.Maximize
+	var front []ParetoPoint
+	for _, w := range weights {
+		eval, err := Weighted(objectives, w)
+		if err != nil {
+			return nil, err
+		}
+		result, err := OptimizeWithOptions(eval, dims, opts)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]float64, len(objectives))
+		for i, obj := range objectives {
+			values[i] = obj(result.Best)
+		}
+		front = append(front, ParetoPoint{Weights: w, Point: result.Best, Values: values})
+	}
+
+	var kept []ParetoPoint
+	for i, p := range front {
+		dominated := false
+		for j, q := range front {
+			if i != j && dominates(q.Values, p.Values, maximize) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			kept = append(kept, p)
+		}
+	}
+	return kept, nil
+}
+
+// dominates reports whether a is at least as good as b in every
+// objective and better in at least one
+func dominates(a, b []float64, maximize bool) bool {
+	better := false
+	for i := range a {
+		x, y := a[i], b[i]
+		if maximize {
+			x, y = -x, -y
+		}
+		if x > y {
+			return false
+		}
+		if x < y {
+			better = true
+		}
+	}
+	return better
+}
@@ -0,0 +1,61 @@
+package simplex
+
+import (
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+// distanceTo returns the squared distance from a point to
+// (x, 0)
+func distanceTo(x float64) func(p *Point) float64 {
+	return func(p *Point) float64 {
+		dx, dy := p.Terms[0]-x, p.Terms[1]
+		return dx*dx + dy*dy
+	}
+}
+
+func TestWeighted(t *testing.T) {
+	objectives := []func(p *Point) float64{distanceTo(0), distanceTo(4)}
+	p := &Point{Dims: 2, Terms: []float64{1, 0}}
+	eval, err := Weighted(objectives, []float64{2, 0.5})
+	assert.NoError(t, err)
+	assert.Equal(t, 2*1+0.5*9, eval(p))
+
+	_, err = Weighted(objectives, []float64{1})
+	assert.EqualError(t, err, `Weighted: got 1 weights for 2 objectives`)
+}
+
+func TestParetoFront(t *testing.T) {
+	// The weighted sum w0·|p|² + w1·|p - (4, 0)|² is minimized
+	// at (4·w1/(w0+w1), 0), so the optimum moves from one
+	// minimum to the other as the weights shift
+	objectives := []func(p *Point) float64{distanceTo(0), distanceTo(4)}
+	weights := [][]float64{{1, 0}, {0.75, 0.25}, {0.5, 0.5}, {0.25, 0.75}, {0, 1}}
+	opts := seededOptions(1)
+	opts.FTol = 0
+	opts.XTol = 1e-6
+	opts.MaxIters = 1000
+	front, err := ParetoFront(objectives, weights, 2, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, len(weights), len(front))
+	for i, p := range front {
+		assert.Equal(t, weights[i], p.Weights)
+		assert.InDelta(t, float64(i), p.Point.Terms[0], 1e-3)
+		assert.InDelta(t, 0, p.Point.Terms[1], 1e-3)
+		assert.Equal(t, objectives[1](p.Point), p.Values[1])
+	}
+
+	// Along the front, improving one objective worsens the other
+	for i := 1; i < len(front); i++ {
+		assert.True(t, front[i].Values[0] > front[i-1].Values[0])
+		assert.True(t, front[i].Values[1] < front[i-1].Values[1])
+	}
+}
+
+func TestDominates(t *testing.T) {
+	assert.True(t, dominates([]float64{1, 2}, []float64{1, 3}, false))
+	assert.False(t, dominates([]float64{1, 3}, []float64{1, 3}, false))
+	assert.False(t, dominates([]float64{0, 4}, []float64{1, 3}, false))
+	assert.True(t, dominates([]float64{1, 3}, []float64{1, 2}, true))
+}
@@ -0,0 +1,25 @@
+package simplex
+
+// Constraint measures how far a point is from satisfying an
+// inequality constraint. It returns 0 when the constraint is
+// satisfied and a positive violation otherwise, such as
+// max(0, g(p)) for the constraint g(p) <= 0.
+type Constraint func(p *Point) float64
+
+// Penalized returns an objective which adds weight times the sum
+// of the squared violations of constraints to eval, turning a
+// constrained problem into an unconstrained one. Larger weights
+// keep the minimum closer to the feasible region but make the
+// objective steeper near its edge, so a common approach is to
+// optimize with a small weight and then restart from the result
+// with successively larger ones.
+func Penalized(eval func(p *Point) float64, constraints []Constraint, weight float64) func(p *Point) float64 {
+	return func(p *Point) float64 {
+		penalty := 0.0
+		for _, c := range constraints {
+			v := c(p)
+			penalty += v * v
+		}
+		return eval(p) + weight*penalty
+	}
+}
@@ -0,0 +1,49 @@
+package simplex
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+func TestPenalized(t *testing.T) {
+	eval := func(p *Point) float64 { return 1 }
+	positive := func(p *Point) float64 { return math.Max(0, -p.Terms[0]) }
+	below := func(p *Point) float64 { return math.Max(0, p.Terms[0]-2) }
+	penalized := Penalized(eval, []Constraint{positive, below}, 10)
+
+	assert.Equal(t, 1.0, penalized(&Point{Dims: 1, Terms: []float64{1}}))
+	assert.Equal(t, 1+10*9.0, penalized(&Point{Dims: 1, Terms: []float64{-3}}))
+	assert.Equal(t, 1+10*4.0, penalized(&Point{Dims: 1, Terms: []float64{4}}))
+}
+
+func TestOptimizePenalized(t *testing.T) {
+	// The unconstrained minimum at (3, 4) violates x + y <= 1,
+	// so the constrained minimum is its projection (0, 1)
+	eval := func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-3, 2) + math.Pow(p.Terms[1]-4, 2)
+	}
+	constraints := []Constraint{func(p *Point) float64 {
+		return math.Max(0, p.Terms[0]+p.Terms[1]-1)
+	}}
+
+	// Each increase of the weight restarts from the previous
+	// minimum and moves it closer to the feasible region
+	start := &Point{Dims: 2, Terms: []float64{0, 0}}
+	violation := math.Inf(1)
+	for _, weight := range []float64{1, 100, 1e4, 1e6} {
+		opts := DefaultOptions()
+		opts.FTol = 0
+		opts.XTol = 1e-9
+		opts.MaxIters = 5000
+		result, err := OptimizeFrom(Penalized(eval, constraints, weight),
+			SimplexAround(start, 0.1), opts)
+		assert.NoError(t, err)
+		start = result.Best
+		v := constraints[0](start)
+		assert.True(t, v < violation)
+		violation = v
+	}
+	assert.True(t, start.ApproxEquals(&Point{Dims: 2, Terms: []float64{0, 1}}, 1e-3))
+}
@@ -0,0 +1,47 @@
+package simplex
+
+import "math"
+
+// repairStepFactor is the distance, in multiples of
+// Options.XTol, by which a duplicate vertex is moved away from
+// its twin
+const repairStepFactor = 10
+
+// findDuplicate returns the indices i < j of the first pair of
+// points of s closer together than tol, or -1, -1 if there is
+// none
+func findDuplicate(s *Simplex, tol float64) (int, int) {
+	for i, p := range s.Points {
+		for j := i + 1; j < len(s.Points); j++ {
+			if p.Distance(s.Points[j]) < tol {
+				return i, j
+			}
+		}
+	}
+	return -1, -1
+}
+
+// repairDuplicate replaces point j of s, a near duplicate of the
+// better point i, with point i moved by repairStepFactor times
+// opts.XTol along the axis on which the simplex is narrowest,
+// which is the axis the simplex is most likely to have lost.
+// The new point is evaluated and the simplex is sorted again.
+func repairDuplicate(s *Simplex, i, j int, eval func(p *Point) float64, opts *Options) {
+	axis, narrowest := 0, math.Inf(1)
+	for d := 0; d < s.Dimension; d++ {
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for _, p := range s.Points {
+			lo = math.Min(lo, p.Terms[d])
+			hi = math.Max(hi, p.Terms[d])
+		}
+		if hi-lo < narrowest {
+			axis, narrowest = d, hi-lo
+		}
+	}
+	moved := s.Points[i].Clone()
+	moved.Terms[axis] += repairStepFactor * opts.XTol
+	feasible(moved, opts)
+	s.Points[j] = moved
+	s.Evaluations[j] = eval(moved)
+	s.Sort()
+}
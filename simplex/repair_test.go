@@ -0,0 +1,67 @@
+package simplex
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+func TestRepairDuplicate(t *testing.T) {
+	eval := func(p *Point) float64 { return p.Dot(p) }
+	s := NewSimplex(2)
+	for _, terms := range [][]float64{{1, 1}, {3, 1}, {3, 1}} {
+		p := &Point{Dims: 2, Terms: terms}
+		s.SetPoint(p, eval(p))
+	}
+	i, j := findDuplicate(s, 1e-3)
+	assert.Equal(t, 1, i)
+	assert.Equal(t, 2, j)
+
+	opts := DefaultOptions()
+	opts.XTol = 1e-3
+	repairDuplicate(s, i, j, eval, opts)
+	// The copy moves along y, on which the simplex is flat
+	assert.Equal(t, []float64{1, 1}, s.Points[0].Terms)
+	assert.Equal(t, []float64{3, 1}, s.Points[1].Terms)
+	assert.Equal(t, []float64{3, 1.01}, s.Points[2].Terms)
+	for k, p := range s.Points {
+		assert.Equal(t, eval(p), s.Evaluations[k])
+	}
+	i, j = findDuplicate(s, 1e-3)
+	assert.Equal(t, -1, j)
+	assert.True(t, s.Volume() > 0)
+}
+
+func TestOptimizeRepairDuplicates(t *testing.T) {
+	eval := func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-1, 2) + math.Pow(p.Terms[1]-2, 2)
+	}
+	// Two coincident vertices leave a segment which can never
+	// leave the line y = 5
+	initial := func() []*Point {
+		return []*Point{
+			{Dims: 2, Terms: []float64{5, 5}},
+			{Dims: 2, Terms: []float64{5, 5}},
+			{Dims: 2, Terms: []float64{6, 5}},
+		}
+	}
+	opts := DefaultOptions()
+	opts.FTol = 0
+	opts.XTol = 1e-6
+	opts.MaxIters = 500
+	stuck, err := OptimizeFrom(eval, initial(), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, stuck.Repairs)
+	assert.Equal(t, 5.0, stuck.Best.Terms[1])
+
+	opts.RepairDuplicates = true
+	repaired, err := OptimizeFrom(eval, initial(), opts)
+	assert.NoError(t, err)
+	assert.True(t, repaired.Repairs > 0)
+	assert.InDelta(t, 1, repaired.Best.Terms[0], 1e-3)
+	assert.InDelta(t, 2, repaired.Best.Terms[1], 1e-3)
+
+	opts.XTol = 0
+	assert.Error(t, opts.Validate())
+}
@@ -0,0 +1,183 @@
+package simplex
+
+import "fmt"
+
+// StopReason describes why an optimization stopped
+type StopReason string
+
+const (
+	// StopFTol means the values of the simplex agreed to
+	// within Options.FTol
+	StopFTol StopReason = `function tolerance reached`
+	// StopFTolRel means the values of the simplex agreed to
+	// within Options.FTolRel of the best value
+	StopFTolRel StopReason = `relative function tolerance reached`
+	// StopXTol means the points of the simplex agreed to
+	// within Options.XTol
+	StopXTol StopReason = `point tolerance reached`
+	// StopPatience means the best value improved by no more
+	// than Options.FTol over Options.Patience iterations
+	StopPatience StopReason = `patience exhausted`
+	// StopMinEdge means a shrink brought the shortest edge
+	// of the simplex down to Options.MinEdge
+	StopMinEdge StopReason = `minimum edge length reached`
+	// StopNoProgress means an iteration left every point
+	// and value of the simplex unchanged, as happens once
+	// rounding stops the shrinks of a simplex on a flat
+	// objective from moving its points, so that every later
+	// iteration would do the same. It is not counted as
+	// converging.
+	StopNoProgress StopReason = `no progress`
+	// StopTargetValue means the best value reached
+	// Options.TargetValue
+	StopTargetValue StopReason = `target value reached`
+	// StopTerminateFunc means Options.TerminateFunc asked
+	// for the optimization to stop
+	StopTerminateFunc StopReason = `stopped by termination rule`
+	// StopMaxIters means Options.MaxIters iterations were
+	// performed without converging
+	StopMaxIters StopReason = `iteration limit reached`
+	// StopMaxEvaluations means the objective could not be
+	// evaluated again without exceeding
+	// Options.MaxEvaluations
+	StopMaxEvaluations StopReason = `evaluation limit reached`
+	// StopCallback means Options.Callback asked for the
+	// optimization to stop
+	StopCallback StopReason = `stopped by callback`
+	// StopFailures means the evaluations of a
+	// FallibleObjective failed too many times in a row
+	StopFailures StopReason = `too many failed evaluations`
+)
+
+// Operation is the move by which an iteration changed the
+// simplex
+type Operation int
+
+const (
+	// OpReflect means the reflected point was accepted in place
+	// of the worst point without trying an expansion
+	OpReflect Operation = iota
+	// OpExpand means the reflected point was better than the
+	// best point and the expanded point, which was better
+	// still, was accepted
+	OpExpand
+	// OpContract means the reflected point was no better
+	// than the worst point, and the inside contraction, moved
+	// from the worst point towards the centroid, was accepted
+	OpContract
+	// OpShrink means every point was moved towards the best
+	// point
+	OpShrink
+	// OpInitialReflect means the reflected point was better
+	// than the best point, but the expanded point was not
+	// better than it, or could not be afforded, so the
+	// reflected point was accepted
+	OpInitialReflect
+	// OpContractOutside means the reflected point was better
+	// than the worst point but not the second worst, and the
+	// outside contraction, moved from the reflected point
+	// towards the centroid, was accepted
+	OpContractOutside
+	// OpRestart means the step was followed by a restart,
+	// which rebuilt the simplex around its best point
+	OpRestart
+	// OpRepair means the step was followed by the repair of
+	// a near duplicate vertex
+	OpRepair
+)
+
+func (o Operation) String() string {
+	switch o {
+	case OpReflect:
+		return `reflect`
+	case OpExpand:
+		return `expand`
+	case OpContract:
+		return `contract`
+	case OpShrink:
+		return `shrink`
+	case OpInitialReflect:
+		return `initial reflect`
+	case OpContractOutside:
+		return `outside contract`
+	case OpRestart:
+		return `restart`
+	case OpRepair:
+		return `repair`
+	}
+	return fmt.Sprintf(`Operation(%d)`, int(o))
+}
+
+// Result holds the outcome of an optimization
+type Result struct {
+	// Best is a copy of the best point found
+	Best *Point
+	// BestValue is the objective's value at Best
+	BestValue float64
+	// FinalSimplex is the simplex at the end of the run
+	FinalSimplex *Simplex
+	// Coefficients are the coefficients used by the run, which
+	// differ from Options.Coefficients if Options.Adaptive is
+	// set
+	Coefficients Coefficients
+
+	// Iterations is the number of iterations performed
+	Iterations int
+	// Evaluations is the number of times the objective was
+	// called, which varies from one iteration to the next.
+	// Evaluations answered by the cache are not counted.
+	Evaluations int
+	// Converged is true if one of the tolerances or
+	// Options.TargetValue was met, or Options.TerminateFunc
+	// stopped the optimization, before the iteration limit was
+	// reached
+	Converged bool
+	// StopReason explains why the optimization stopped
+	StopReason StopReason
+	// Operations holds the operation performed by each
+	// iteration of this run, in order. An iteration cut short
+	// by Options.MaxEvaluations performs no operation, so the
+	// last iteration may be missing. An iteration followed by a
+	// restart or a repair is recorded as OpRestart or OpRepair.
+	Operations []Operation
+	// Path holds a copy of the best point after each
+	// iteration of this run when Options.RecordPath is set
+	Path []*Point
+	// BestValues and WorstValues hold the values of the best
+	// and worst points of the simplex after each iteration of
+	// this run when Options.RecordPath is set. The gap between
+	// them shows how fast the simplex is contracting.
+	BestValues  []float64
+	WorstValues []float64
+	// CentroidValues holds the objective's value at the
+	// centroid of the simplex after each iteration of this run
+	// when Options.RecordCentroid is set. The value is NaN if
+	// evaluating it would have exceeded Options.MaxEvaluations.
+	CentroidValues []float64
+	// Gradient is the gradient of the objective at Best
+	// estimated by finite differences when
+	// Options.GradientStep is set, or nil if the evaluations
+	// it needs would have exceeded Options.MaxEvaluations
+	Gradient *Point
+
+	// BadEvaluations is the number of times the objective
+	// returned NaN or an infinity. Such values are treated as
+	// worse than any finite value.
+	BadEvaluations int
+	// CacheHits is the number of evaluations answered from the
+	// cache when Options.Cache is set
+	CacheHits int
+
+	// Repairs is the number of near duplicate vertices moved
+	// apart because of Options.RepairDuplicates
+	Repairs int
+
+	// Restarts is the number of times the simplex was rebuilt
+	// because of Options.RestartOnStall,
+	// Options.RestartAtMinEdge or Options.RestartOnNoProgress
+	Restarts int
+
+	// RestartValues holds the best value found by each run of
+	// OptimizeMultiStart, in the order the runs were started
+	RestartValues []float64
+}
@@ -0,0 +1,175 @@
+package simplex
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// InitStrategy selects how the points of a random initial
+// simplex are placed within Options.Bounds or [0, Spread)
+type InitStrategy int
+
+const (
+	// InitRandom draws every coordinate independently and
+	// uniformly
+	InitRandom InitStrategy = iota
+	// InitLatinHypercube splits each dimension into one
+	// stratum per point and draws exactly one point from each
+	// stratum of each dimension
+	InitLatinHypercube
+	// InitGrid places the points at the centers of a regular
+	// grid with one cell per point along each dimension, with
+	// point i in cell (i + d) mod (dims+1) of dimension d. The
+	// points never depend on Options.Rand and never form a
+	// degenerate simplex.
+	InitGrid
+)
+
+func (s InitStrategy) String() string {
+	switch s {
+	case InitRandom:
+		return `random`
+	case InitLatinHypercube:
+		return `latin hypercube`
+	case InitGrid:
+		return `grid`
+	}
+	return fmt.Sprintf(`InitStrategy(%d)`, int(s))
+}
+
+// spreadBounds returns the box [0, spread) in each of dims
+// dimensions, with dimension d stretched by scale[d] if scale
+// holds one factor per dimension
+func spreadBounds(dims int, spread float64, scale []float64) [][2]float64 {
+	bounds := make([][2]float64, dims)
+	for d := range bounds {
+		bounds[d] = [2]float64{0, spread}
+		if len(scale) == dims {
+			bounds[d][1] *= scale[d]
+		}
+	}
+	return bounds
+}
+
+// initPointsLatinHypercube generates count points within bounds
+// such that, in every dimension, each of count equal strata
+// holds exactly one point
+func initPointsLatinHypercube(rng *rand.Rand, count int, bounds [][2]float64) []*Point {
+	points := make([]*Point, count)
+	for i := range points {
+		points[i] = NewPoint(len(bounds))
+	}
+	for d, b := range bounds {
+		width := (b[1] - b[0]) / float64(count)
+		for i, stratum := range rng.Perm(count) {
+			points[i].Terms[d] = b[0] + (float64(stratum)+rng.Float64())*width
+		}
+	}
+	return points
+}
+
+// initPointsGrid generates count points at the centers of the
+// cells of a regular grid over bounds as described by InitGrid
+func initPointsGrid(count int, bounds [][2]float64) []*Point {
+	points := make([]*Point, count)
+	for i := range points {
+		points[i] = NewPoint(len(bounds))
+		for d, b := range bounds {
+			cell := (i + d) % count
+			width := (b[1] - b[0]) / float64(count)
+			points[i].Terms[d] = b[0] + (float64(cell)+0.5)*width
+		}
+	}
+	return points
+}
+
+// validateInitBox checks that every dimension of box has a
+// positive width. An empty box is valid.
+func validateInitBox(box [][2]float64) error {
+	for d, b := range box {
+		if !(b[0] < b[1]) {
+			return fmt.Errorf(`Options: InitBox for dimension %d must have positive width, got %v`, d, b)
+		}
+	}
+	return nil
+}
+
+// intersectBox returns the part of box which lies inside bounds.
+// Dimensions in which they do not overlap have a width of zero
+// or less.
+func intersectBox(box, bounds [][2]float64) [][2]float64 {
+	ret := make([][2]float64, len(box))
+	for d, b := range box {
+		ret[d] = [2]float64{math.Max(b[0], bounds[d][0]), math.Min(b[1], bounds[d][1])}
+	}
+	return ret
+}
+
+// initPointsInBox generates the points of a simplex within box
+// whose first point is drawn using rng and whose point d+1 lies
+// fraction of the width of dimension d from the first point
+// along that axis
+func initPointsInBox(rng *rand.Rand, box [][2]float64, fraction float64) []*Point {
+	steps := make([]float64, len(box))
+	for d, b := range box {
+		steps[d] = fraction * (b[1] - b[0])
+	}
+	return initPointsWithSteps(rng, box, steps)
+}
+
+// initPointsWithSteps generates the points of a simplex whose
+// first point is drawn from box using rng and whose point d+1
+// lies steps[d] from the first point along axis d. The first
+// point is drawn from the part of the box which leaves room for
+// the other points, or placed at the lower limit of dimensions
+// narrower than their step.
+func initPointsWithSteps(rng *rand.Rand, box [][2]float64, steps []float64) []*Point {
+	base := NewPoint(len(box))
+	for d, b := range box {
+		room := math.Max(0, b[1]-b[0]-steps[d])
+		base.Terms[d] = b[0] + rng.Float64()*room
+	}
+	return SimplexAroundSteps(base, steps)
+}
+
+const (
+	// maxInitAttempts is the number of times a degenerate
+	// initial simplex is drawn before falling back to InitGrid
+	maxInitAttempts = 10
+	// minInitVolume is the smallest volume of an initial
+	// simplex relative to the corner simplex of the bounds,
+	// formed by one corner and its neighboring corners
+	minInitVolume = 1e-6
+)
+
+// nondegenerate returns the first of up to maxInitAttempts sets of
+// points returned by draw whose simplex has at least minInitVolume
+// of the corner simplex of bounds, falling back to the grid points
+// of InitGrid, which are never degenerate. Bounds of zero width
+// make every simplex degenerate, so the first set is used.
+func nondegenerate(draw func() []*Point, bounds [][2]float64) []*Point {
+	// The corner simplex has volume Π width / n!
+	corner := 1.0
+	for d, b := range bounds {
+		corner *= (b[1] - b[0]) / float64(d+1)
+	}
+	points := draw()
+	if corner == 0 {
+		return points
+	}
+	for attempt := 1; ; attempt++ {
+		s := &Simplex{
+			Points:      points,
+			Dimension:   len(bounds),
+			Evaluations: make([]float64, len(points)),
+		}
+		if s.Volume() >= minInitVolume*corner {
+			return points
+		}
+		if attempt == maxInitAttempts {
+			return initPointsGrid(len(points), bounds)
+		}
+		points = draw()
+	}
+}
@@ -0,0 +1,239 @@
+package simplex
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+// strata returns the stratum of each point along dimension d
+// when bounds[d] is split into len(points) equal strata
+func strata(points []*Point, bounds [][2]float64, d int) []int {
+	count := float64(len(points))
+	ret := make([]int, len(points))
+	for i, p := range points {
+		lo, hi := bounds[d][0], bounds[d][1]
+		ret[i] = int(math.Floor((p.Terms[d] - lo) / (hi - lo) * count))
+	}
+	return ret
+}
+
+func TestInitPointsLatinHypercube(t *testing.T) {
+	bounds := [][2]float64{{0, 10}, {-5, 5}, {100, 101}}
+	rng := rand.New(rand.NewSource(1))
+	points := initPointsLatinHypercube(rng, 4, bounds)
+	assert.Equal(t, 4, len(points))
+	for d := range bounds {
+		// Each stratum holds exactly one point
+		s := strata(points, bounds, d)
+		sort.Ints(s)
+		assert.Equal(t, []int{0, 1, 2, 3}, s)
+	}
+}
+
+func TestInitPointsGrid(t *testing.T) {
+	bounds := [][2]float64{{0, 3}, {0, 6}}
+	points := initPointsGrid(3, bounds)
+	assert.Equal(t, []float64{0.5, 3}, points[0].Terms)
+	assert.Equal(t, []float64{1.5, 5}, points[1].Terms)
+	assert.Equal(t, []float64{2.5, 1}, points[2].Terms)
+
+	// The grid never forms a degenerate simplex
+	for dims := 1; dims <= 6; dims++ {
+		s := NewSimplex(dims)
+		for i, p := range initPointsGrid(dims+1, spreadBounds(dims, 1, nil)) {
+			s.SetPoint(p, float64(i))
+		}
+		assert.True(t, s.Volume() > 0)
+	}
+}
+
+func TestOptimizeInitStrategy(t *testing.T) {
+	eval := func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-3, 2) + math.Pow(p.Terms[1]-4, 2)
+	}
+	for _, strategy := range []InitStrategy{InitRandom, InitLatinHypercube, InitGrid} {
+		opts := seededOptions(1)
+		opts.InitStrategy = strategy
+		opts.FTol = 1e-12
+		opts.MaxIters = 1000
+		result, err := OptimizeWithOptions(eval, 2, opts)
+		assert.NoError(t, err)
+		assert.InDelta(t, 3, result.Best.Terms[0], 1e-3)
+		assert.InDelta(t, 4, result.Best.Terms[1], 1e-3)
+	}
+}
+
+func TestNondegenerate(t *testing.T) {
+	bounds := [][2]float64{{0, 10}, {0, 10}}
+	collinear := func() []*Point {
+		return []*Point{
+			{Dims: 2, Terms: []float64{1, 1}},
+			{Dims: 2, Terms: []float64{2, 2}},
+			{Dims: 2, Terms: []float64{3, 3}},
+		}
+	}
+	good := []*Point{
+		{Dims: 2, Terms: []float64{1, 1}},
+		{Dims: 2, Terms: []float64{5, 1}},
+		{Dims: 2, Terms: []float64{1, 5}},
+	}
+
+	// A degenerate draw is rejected and drawn again
+	draws := 0
+	points := nondegenerate(func() []*Point {
+		draws++
+		if draws == 1 {
+			return collinear()
+		}
+		return good
+	}, bounds)
+	assert.Equal(t, 2, draws)
+	assert.Equal(t, good, points)
+
+	// After too many degenerate draws the grid is used
+	draws = 0
+	points = nondegenerate(func() []*Point {
+		draws++
+		return collinear()
+	}, bounds)
+	assert.Equal(t, maxInitAttempts, draws)
+	assert.Equal(t, initPointsGrid(3, bounds), points)
+}
+
+func TestInitPointsInBox(t *testing.T) {
+	box := [][2]float64{{0, 1000}, {0, 1000}}
+	rng := rand.New(rand.NewSource(1))
+	points := initPointsInBox(rng, box, 0.1)
+	assert.Equal(t, 3, len(points))
+	for _, p := range points {
+		for d, b := range box {
+			assert.True(t, p.Terms[d] >= b[0] && p.Terms[d] <= b[1])
+		}
+	}
+	// Each edge from the first point spans a tenth of the box
+	assert.InDelta(t, 100, points[1].Terms[0]-points[0].Terms[0], 1e-9)
+	assert.Equal(t, points[0].Terms[1], points[1].Terms[1])
+	assert.InDelta(t, 100, points[2].Terms[1]-points[0].Terms[1], 1e-9)
+	assert.Equal(t, points[0].Terms[0], points[2].Terms[0])
+}
+
+func TestOptimizeInitBox(t *testing.T) {
+	eval := func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-700, 2) + math.Pow(p.Terms[1]-300, 2)
+	}
+	var first *Simplex
+	opts := seededOptions(1)
+	opts.InitBox = [][2]float64{{0, 1000}, {0, 1000}}
+	opts.FTol = 1e-9
+	opts.MaxIters = 1000
+	opts.Callback = func(iter int, s *Simplex) bool {
+		if first == nil {
+			first = s.Clone()
+		}
+		return true
+	}
+	result, err := OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+	assert.InDelta(t, 700, result.Best.Terms[0], 1e-2)
+	assert.InDelta(t, 300, result.Best.Terms[1], 1e-2)
+	// The simplex after one step is still on the order of a
+	// tenth of the box rather than of the default Spread
+	minX, maxX := first.Points[0].Terms[0], first.Points[0].Terms[0]
+	for _, p := range first.Points {
+		minX = math.Min(minX, p.Terms[0])
+		maxX = math.Max(maxX, p.Terms[0])
+	}
+	assert.True(t, maxX-minX >= 50 && maxX-minX <= 400)
+
+	// Configured bounds take precedence over the box, which is
+	// cut down to them rather than having its points clamped
+	// onto each other
+	opts = seededOptions(1)
+	opts.InitBox = [][2]float64{{0, 1000}}
+	opts.Bounds = [][2]float64{{0, 10}}
+	opts.MaxIters = 1
+	result, err = OptimizeWithOptions(func(p *Point) float64 { return p.Terms[0] }, 1, opts)
+	assert.NoError(t, err)
+	for _, p := range result.FinalSimplex.Points {
+		assert.True(t, p.Terms[0] >= 0 && p.Terms[0] <= 10)
+	}
+	initial, err := BuildSimplex(func(p *Point) float64 { return p.Terms[0] },
+		randomPoints(rand.New(rand.NewSource(1)), 1, opts))
+	assert.NoError(t, err)
+	assert.True(t, initial.Volume() > 0)
+	for _, p := range initial.Points {
+		assert.True(t, p.Terms[0] >= 0 && p.Terms[0] <= 10)
+	}
+
+	opts = seededOptions(1)
+	opts.InitBox = [][2]float64{{20, 30}}
+	opts.Bounds = [][2]float64{{0, 10}}
+	_, err = OptimizeWithOptions(func(p *Point) float64 { return p.Terms[0] }, 1, opts)
+	assert.EqualError(t, err, `Options: InitBox for dimension 0 does not overlap Bounds`)
+
+	opts = seededOptions(1)
+	opts.InitBox = [][2]float64{{0, 1000}}
+	_, err = OptimizeWithOptions(eval, 2, opts)
+	assert.EqualError(t, err, `Options: got InitBox for 1 dimensions, expected 2`)
+	opts.InitBox = [][2]float64{{5, 5}}
+	assert.Error(t, opts.Validate())
+	opts.InitBox = [][2]float64{{0, 1}}
+	opts.InitFraction = 0
+	assert.Error(t, opts.Validate())
+}
+
+func TestInitialStep(t *testing.T) {
+	// edges returns the distance from the first point to each
+	// of the others
+	edges := func(points []*Point) []float64 {
+		ret := make([]float64, len(points)-1)
+		for i, p := range points[1:] {
+			ret[i] = p.Distance(points[0])
+		}
+		return ret
+	}
+	opts := seededOptions(1)
+	opts.InitialStep = 0.5
+	points := randomPoints(optionsRand(opts), 3, opts)
+	assert.Equal(t, []float64{0.5, 0.5, 0.5}, edges(points))
+	for _, p := range points {
+		for _, v := range p.Terms {
+			assert.True(t, v >= 0 && v < opts.Spread)
+		}
+	}
+
+	// The step is measured in scaled coordinates
+	opts.Scale = []float64{1, 10, 100}
+	assert.Equal(t, []float64{0.5, 5, 50}, edges(randomPoints(optionsRand(opts), 3, opts)))
+
+	// Points are kept inside the bounds, leaving room for the
+	// step where there is some
+	opts = seededOptions(1)
+	opts.InitialStep = 2
+	opts.Bounds = [][2]float64{{0, 10}, {0, 1}}
+	points = randomPoints(optionsRand(opts), 2, opts)
+	assert.Equal(t, []float64{2, 1}, edges(points))
+	assert.Equal(t, 0.0, points[0].Terms[1])
+
+	result, err := OptimizeWithOptions(func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-3, 2) + math.Pow(p.Terms[1]-0.5, 2)
+	}, 2, opts)
+	assert.NoError(t, err)
+	assert.InDelta(t, 3, result.Best.Terms[0], 0.1)
+
+	opts.InitBox = [][2]float64{{0, 1}, {0, 1}}
+	assert.Error(t, opts.Validate())
+	opts.InitBox = nil
+	// The step would be silently ignored by the other
+	// strategies
+	opts.InitStrategy = InitLatinHypercube
+	assert.EqualError(t, opts.Validate(),
+		`Options: InitialStep cannot be combined with the latin hypercube strategy`)
+	opts.InitStrategy = InitRandom
+	opts.InitialStep = -1
+	assert.Error(t, opts.Validate())
+}
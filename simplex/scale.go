@@ -0,0 +1,62 @@
+package simplex
+
+import "fmt"
+
+// validateScale checks that scale holds a factor for each of
+// dims dimensions. An empty scale is valid.
+func validateScale(scale []float64, dims int) error {
+	if scale == nil {
+		return nil
+	}
+	if len(scale) != dims {
+		return fmt.Errorf(`Options: got scale for %d dimensions, expected %d`,
+			len(scale), dims)
+	}
+	return nil
+}
+
+// scaleDown returns a copy of p with each term d divided by
+// scale[d], which maps p into the scaled space
+func scaleDown(p *Point, scale []float64) *Point {
+	ret := NewPoint(p.Dims)
+	for d, v := range p.Terms {
+		ret.Terms[d] = v / scale[d]
+	}
+	return ret
+}
+
+// scaleUp returns a copy of p with each term d multiplied by
+// scale[d], which maps p out of the scaled space
+func scaleUp(p *Point, scale []float64) *Point {
+	ret := NewPoint(p.Dims)
+	for d, v := range p.Terms {
+		ret.Terms[d] = v * scale[d]
+	}
+	return ret
+}
+
+// scaleSimplex returns a copy of s with every point mapped by
+// f, or s itself if scale is empty
+func scaleSimplex(s *Simplex, scale []float64, f func(*Point, []float64) *Point) *Simplex {
+	if scale == nil {
+		return s
+	}
+	ret := s.Clone()
+	for i, p := range s.Points {
+		ret.Points[i] = f(p, scale)
+	}
+	return ret
+}
+
+// scaleBounds returns bounds with the limits of dimension d
+// divided by scale[d]
+func scaleBounds(bounds [][2]float64, scale []float64) [][2]float64 {
+	if bounds == nil {
+		return nil
+	}
+	ret := make([][2]float64, len(bounds))
+	for d, b := range bounds {
+		ret[d] = [2]float64{b[0] / scale[d], b[1] / scale[d]}
+	}
+	return ret
+}
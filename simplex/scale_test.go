@@ -0,0 +1,72 @@
+package simplex
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+func TestOptimizeScale(t *testing.T) {
+	// y is a million times larger than x, so the simplex is
+	// a million times longer along y than along x
+	eval := func(p *Point) float64 {
+		x, y := p.Terms[0], p.Terms[1]
+		return math.Pow(x-0.3, 2) + math.Pow((y-4e5)/1e6, 2)
+	}
+	run := func(scale []float64) *Result {
+		opts := seededOptions(1)
+		opts.Bounds = [][2]float64{{0, 1}, {0, 1e6}}
+		opts.Scale = scale
+		opts.FTol = 0
+		opts.XTol = 1e-6
+		opts.MaxIters = 2000
+		result, err := OptimizeWithOptions(eval, 2, opts)
+		assert.NoError(t, err)
+		return result
+	}
+	unscaled := run(nil)
+	scaled := run([]float64{1, 1e6})
+	t.Logf(`unscaled: %d iterations, scaled: %d iterations`,
+		unscaled.Iterations, scaled.Iterations)
+
+	assert.True(t, scaled.Converged)
+	assert.True(t, scaled.Iterations < unscaled.Iterations)
+	// The result is reported in the original coordinates
+	assert.InDelta(t, 0.3, scaled.Best.Terms[0], 1e-5)
+	assert.InDelta(t, 4e5, scaled.Best.Terms[1], 10)
+	for i, p := range scaled.FinalSimplex.Points {
+		assert.Equal(t, eval(p), scaled.FinalSimplex.Evaluations[i])
+	}
+}
+
+func TestOptimizeScaleCallback(t *testing.T) {
+	eval := func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-300, 2)
+	}
+	initial := []*Point{
+		{Dims: 1, Terms: []float64{100}},
+		{Dims: 1, Terms: []float64{200}},
+	}
+	opts := DefaultOptions()
+	opts.Scale = []float64{100}
+	opts.MaxIters = 3
+	opts.Callback = func(iter int, s *Simplex) bool {
+		// The callback sees the original coordinates
+		for i, p := range s.Points {
+			assert.Equal(t, eval(p), s.Evaluations[i])
+		}
+		return true
+	}
+	_, err := OptimizeFrom(eval, initial, opts)
+	assert.NoError(t, err)
+	// The initial points are not modified
+	assert.Equal(t, []float64{100}, initial[0].Terms)
+
+	opts.Scale = []float64{1, 2}
+	_, err = OptimizeFrom(eval, initial, opts)
+	assert.Error(t, err)
+	opts.Scale = []float64{0}
+	_, err = OptimizeFrom(eval, initial, opts)
+	assert.Error(t, err)
+}
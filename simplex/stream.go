@@ -0,0 +1,73 @@
+package simplex
+
+// IterationEvent describes the state of an optimization after
+// one of its iterations
+type IterationEvent struct {
+	// Iteration is the number of iterations performed so far
+	Iteration int
+	// Operation is the operation performed by the iteration
+	Operation Operation
+	// BestValue is the objective's value at the best point of
+	// the simplex
+	BestValue float64
+	// Simplex is a copy of the simplex after the iteration,
+	// which the receiver may keep or modify
+	Simplex *Simplex
+}
+
+// OptimizeStream runs OptimizeWithOptions in a new goroutine and
+// sends an IterationEvent on the first returned channel after
+// every iteration. Once the optimization stops the Result is
+// sent on the second channel and both channels are closed.
+//
+// The events channel is unbuffered, so the optimization waits
+// for each event to be received and the channel must be drained
+// until it is closed. To stop early, return false from
+// opts.Callback, which is called after each event is sent.
+// Invalid options, and options which do not match dims, are
+// reported by the returned error before the optimization
+// starts. If writing to opts.TraceWriter or opts.HistoryWriter
+// fails, the channels are closed without a Result being sent.
+func OptimizeStream(eval func(p *Point) float64, dims int, opts *Options) (<-chan IterationEvent, <-chan Result, error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := checkRandomDims(dims, opts); err != nil {
+		return nil, nil, err
+	}
+	if err := validateDims(dims, opts); err != nil {
+		return nil, nil, err
+	}
+	events := make(chan IterationEvent)
+	results := make(chan Result, 1)
+	streamOpts := *opts
+	streamOpts.events = events
+	go func() {
+		defer close(results)
+		defer close(events)
+		result, err := OptimizeWithOptions(eval, dims, &streamOpts)
+		if err == nil {
+			results <- *result
+		}
+	}()
+	return events, results, nil
+}
+
+// newIterationEvent returns the event sent after the latest
+// iteration of result, whose simplex is s in the original
+// coordinates
+func newIterationEvent(result *Result, s *Simplex, maximize bool) IterationEvent {
+	value := s.Cost()
+	if maximize {
+		value = -value
+	}
+	return IterationEvent{
+		Iteration: result.Iterations,
+		Operation: result.Operations[len(result.Operations)-1],
+		BestValue: value,
+		Simplex:   s.Clone(),
+	}
+}
@@ -0,0 +1,97 @@
+package simplex
+
+import (
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+func TestOptimizeStream(t *testing.T) {
+	eval := func(p *Point) float64 { return p.Dot(p) }
+	opts := seededOptions(1)
+	opts.FTol = 1e-6
+	opts.MaxIters = 200
+	events, results, err := OptimizeStream(eval, 2, opts)
+	assert.NoError(t, err)
+
+	var ops []Operation
+	for e := range events {
+		assert.Equal(t, len(ops)+1, e.Iteration)
+		assert.Equal(t, e.Simplex.Cost(), e.BestValue)
+		ops = append(ops, e.Operation)
+	}
+	result, ok := <-results
+	assert.True(t, ok)
+	assert.True(t, result.Converged)
+	assert.Equal(t, result.Iterations, len(ops))
+	assert.Equal(t, result.Operations, ops)
+
+	_, ok = <-results
+	assert.False(t, ok)
+}
+
+func TestOptimizeStreamStop(t *testing.T) {
+	eval := func(p *Point) float64 { return p.Dot(p) }
+	opts := seededOptions(1)
+	opts.FTol = 0
+	opts.MaxIters = 200
+	opts.Callback = func(iter int, s *Simplex) bool { return iter < 3 }
+	events, results, err := OptimizeStream(eval, 2, opts)
+	assert.NoError(t, err)
+
+	count := 0
+	for range events {
+		count++
+	}
+	result := <-results
+	assert.Equal(t, 3, count)
+	assert.Equal(t, StopCallback, result.StopReason)
+
+	opts.FTol = -1
+	_, _, err = OptimizeStream(eval, 2, opts)
+	assert.Error(t, err)
+}
+
+func TestOptimizeStreamDims(t *testing.T) {
+	eval := func(p *Point) float64 { return p.Dot(p) }
+	_, _, err := OptimizeStream(eval, 0, seededOptions(1))
+	assert.EqualError(t, err, `need at least 1 dimension, got 0`)
+
+	opts := seededOptions(1)
+	opts.Bounds = [][2]float64{{-1, 1}}
+	events, results, err := OptimizeStream(eval, 2, opts)
+	assert.EqualError(t, err, `Options: got bounds for 1 dimensions, expected 2`)
+	assert.Nil(t, events)
+	assert.Nil(t, results)
+
+	opts = seededOptions(1)
+	opts.Scale = []float64{1, 2, 3}
+	_, _, err = OptimizeStream(eval, 2, opts)
+	assert.EqualError(t, err, `Options: got scale for 3 dimensions, expected 2`)
+}
+
+func TestOptimizeStreamRestart(t *testing.T) {
+	// On a flat objective every iteration shrinks until the
+	// shrinks stop making progress and the simplex is restarted
+	opts := seededOptions(1)
+	opts.FTol = 0
+	opts.MaxIters = 2000
+	opts.RestartOnNoProgress = true
+	events, results, err := OptimizeStream(func(p *Point) float64 { return 1 }, 2, opts)
+	assert.NoError(t, err)
+
+	var ops []Operation
+	restarts := 0
+	for e := range events {
+		ops = append(ops, e.Operation)
+		if e.Operation == OpRestart {
+			restarts++
+			// The event carries the simplex the restart rebuilt
+			assert.InDelta(t, opts.RestartStep, e.Simplex.shortestEdge(), 1e-12)
+		}
+	}
+	result := <-results
+	assert.True(t, result.Restarts > 0)
+	assert.Equal(t, result.Restarts, restarts)
+	assert.Equal(t, result.Operations, ops)
+}
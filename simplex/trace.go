@@ -0,0 +1,108 @@
+package simplex
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// writeSimplex writes s to w in the trace format
+func writeSimplex(s *Simplex, w io.Writer) error {
+	// For xi = (xi1, xi2, ..., xin), zi = eval(xi)
+	// Print the Simplex in the format
+	// Simplex
+	// x11,x12,...,x1n,z1
+	// x21,x22,...,x2n,z2
+	// ..
+	// xn1,xn2,...,x(n+1)n, zn+1
+	// End
+	_, err := io.WriteString(w, "Simplex\n")
+	if err != nil {
+		return err
+	}
+	for i, p := range s.Points {
+		terms := make([]string, len(p.Terms))
+		for j, d := range p.Terms {
+			terms[j] = fmt.Sprintf("%f", d)
+		}
+		terms = append(terms, fmt.Sprintf("%f", s.Evaluations[i]))
+		_, err = io.WriteString(w, strings.Join(terms, `,`)+"\n")
+		if err != nil {
+			return err
+		}
+	}
+	_, err = io.WriteString(w, "End\n")
+	return err
+}
+
+// ParseSimplexTrace reads back a sequence of simplexes written
+// in the trace format. Each block must hold dims+1 lines of
+// dims coordinates followed by their evaluation.
+func ParseSimplexTrace(r io.Reader) ([]*Simplex, error) {
+	var (
+		simplexes []*Simplex
+		current   *Simplex
+		lineNum   int
+	)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case current == nil && line == ``:
+			continue
+		case current == nil && line == `Simplex`:
+			current = &Simplex{
+				Points:      make([]*Point, 0),
+				Evaluations: make([]float64, 0),
+				Dimension:   -1,
+			}
+		case current == nil:
+			return nil, fmt.Errorf(`line %d: expected "Simplex", got %q`, lineNum, line)
+		case line == `End`:
+			if len(current.Points) == 0 {
+				return nil, fmt.Errorf(`line %d: simplex has no points`, lineNum)
+			}
+			if len(current.Points) != current.Dimension+1 {
+				return nil, fmt.Errorf(`line %d: got %d points for %d dimensions`,
+					lineNum, len(current.Points), current.Dimension)
+			}
+			current.numInitialized = len(current.Points)
+			simplexes = append(simplexes, current)
+			current = nil
+		default:
+			fields := strings.Split(line, `,`)
+			if len(fields) < 2 {
+				return nil, fmt.Errorf(`line %d: expected coordinates and a value, got %q`,
+					lineNum, line)
+			}
+			if current.Dimension == -1 {
+				current.Dimension = len(fields) - 1
+			} else if len(fields) != current.Dimension+1 {
+				return nil, fmt.Errorf(`line %d: got %d columns, expected %d`,
+					lineNum, len(fields), current.Dimension+1)
+			}
+			values := make([]float64, len(fields))
+			for i, f := range fields {
+				v, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+				if err != nil {
+					return nil, fmt.Errorf(`line %d: %v`, lineNum, err)
+				}
+				values[i] = v
+			}
+			p := NewPoint(current.Dimension)
+			copy(p.Terms, values)
+			current.Points = append(current.Points, p)
+			current.Evaluations = append(current.Evaluations, values[current.Dimension])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if current != nil {
+		return nil, fmt.Errorf(`line %d: trace ends inside a simplex`, lineNum)
+	}
+	return simplexes, nil
+}
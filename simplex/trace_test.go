@@ -0,0 +1,124 @@
+package simplex
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+func TestWriteSimplex(t *testing.T) {
+	s := NewSimplex(2)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{1, 2}}, 3)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{4, 5}}, 6)
+	s.SetPoint(&Point{Dims: 2, Terms: []float64{7, 8}}, 9)
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeSimplex(s, &buf))
+	expected := "Simplex\n" +
+		"1.000000,2.000000,3.000000\n" +
+		"4.000000,5.000000,6.000000\n" +
+		"7.000000,8.000000,9.000000\n" +
+		"End\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+// update rewrites the golden files with the current output
+// instead of comparing against them: go test ./simplex -update
+var update = flag.Bool(`update`, false, `update golden files`)
+
+// TestWriteSimplexGolden pins the exact bytes of the trace
+// format, which ParseSimplexTrace and external tools read. Each
+// simplex is written as:
+//
+//   - a line holding exactly "Simplex"
+//   - one line per point in the simplex's order, holding the
+//     point's terms followed by its value, each formatted with
+//     %f (six digits after the decimal point, no exponent, and
+//     a leading '-' for every negative number, even one which
+//     rounds to -0.000000) and separated by commas without
+//     spaces
+//   - a line holding exactly "End"
+//
+// Every line, including the last, ends with a single '\n', and
+// consecutive simplexes follow each other without separators.
+func TestWriteSimplexGolden(t *testing.T) {
+	first := NewSimplex(3)
+	first.SetPoint(&Point{Dims: 3, Terms: []float64{-1.5, 0.25, 3}}, 12.125)
+	first.SetPoint(&Point{Dims: 3, Terms: []float64{2, -0.0000005, -7.75}}, -0.5)
+	first.SetPoint(&Point{Dims: 3, Terms: []float64{0, 1e6, 0.1}}, 1e-7)
+	first.SetPoint(&Point{Dims: 3, Terms: []float64{-1234.5678, 0.333333333, -2}}, 42)
+	second := NewSimplex(1)
+	second.SetPoint(&Point{Dims: 1, Terms: []float64{-0.001}}, 3.14159265)
+	second.SetPoint(&Point{Dims: 1, Terms: []float64{100}}, -2.5)
+
+	var buf bytes.Buffer
+	for _, s := range []*Simplex{first, second} {
+		assert.NoError(t, writeSimplex(s, &buf))
+	}
+
+	golden := filepath.Join(`testdata`, `trace.golden`)
+	if *update {
+		assert.NoError(t, ioutil.WriteFile(golden, buf.Bytes(), 0644))
+	}
+	expected, err := ioutil.ReadFile(golden)
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), buf.String())
+}
+
+func TestOptimizeTraceWriter(t *testing.T) {
+	eval := func(p *Point) float64 {
+		return math.Pow(p.Terms[0]-3, 2) + math.Pow(p.Terms[1]-4, 2)
+	}
+	var buf bytes.Buffer
+	opts := seededOptions(1)
+	opts.TraceWriter = &buf
+	result, err := OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+
+	// One block for the initial simplex and one per iteration
+	trace := buf.String()
+	assert.Equal(t, result.Iterations+1, strings.Count(trace, "Simplex\n"))
+	assert.Equal(t, result.Iterations+1, strings.Count(trace, "End\n"))
+	assert.True(t, strings.HasPrefix(trace, "Simplex\n"))
+	assert.True(t, strings.HasSuffix(trace, "End\n"))
+}
+
+func TestParseSimplexTrace(t *testing.T) {
+	first := NewSimplex(2)
+	first.SetPoint(&Point{Dims: 2, Terms: []float64{1, 2}}, 3)
+	first.SetPoint(&Point{Dims: 2, Terms: []float64{-4.5, 5}}, 6)
+	first.SetPoint(&Point{Dims: 2, Terms: []float64{7, 0.25}}, 9)
+	second := NewSimplex(1)
+	second.SetPoint(&Point{Dims: 1, Terms: []float64{-1}}, 0.5)
+	second.SetPoint(&Point{Dims: 1, Terms: []float64{2}}, 1.75)
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeSimplex(first, &buf))
+	assert.NoError(t, writeSimplex(second, &buf))
+
+	parsed, err := ParseSimplexTrace(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Simplex{first, second}, parsed)
+}
+
+func TestParseSimplexTraceErrors(t *testing.T) {
+	cases := map[string]string{
+		"Simplex\n1,2,x\nEnd\n":        `line 2: strconv.ParseFloat: parsing "x": invalid syntax`,
+		"Simplex\n1,2,3\n4,5\nEnd\n":   `line 3: got 2 columns, expected 3`,
+		"Simplex\n1,2,3\n4,5,6\n":      `line 3: trace ends inside a simplex`,
+		"Simplex\n1,2,3\n4,5,6\nEnd\n": `line 4: got 2 points for 2 dimensions`,
+		"1,2\n":                        `line 1: expected "Simplex", got "1,2"`,
+		"Simplex\nEnd\n":               `line 2: simplex has no points`,
+		"Simplex\n1\nEnd\n":            `line 2: expected coordinates and a value, got "1"`,
+	}
+	for trace, expected := range cases {
+		_, err := ParseSimplexTrace(strings.NewReader(trace))
+		assert.EqualError(t, err, expected, trace)
+	}
+}
@@ -0,0 +1,55 @@
+package simplex
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TracedObjective wraps an Objective and writes every point it
+// evaluates, with the value returned, to a writer. It is safe
+// for concurrent use if the wrapped Objective is, though the
+// lines of concurrent evaluations may appear in any order.
+type TracedObjective struct {
+	obj Objective
+	w   io.Writer
+	mu  sync.Mutex
+	err error
+}
+
+// Traced returns a TracedObjective which writes one line per
+// evaluation of obj to w, holding the terms of the point
+// followed by its value, separated by commas and in full
+// precision
+func Traced(obj Objective, w io.Writer) *TracedObjective {
+	return &TracedObjective{obj: obj, w: w}
+}
+
+// Evaluate returns the value of the wrapped Objective at p and
+// writes the pair to the trace. Once a write fails nothing more
+// is written, but evaluations continue.
+func (t *TracedObjective) Evaluate(p *Point) float64 {
+	value := t.obj.Evaluate(p)
+	fields := make([]string, len(p.Terms)+1)
+	for d, v := range p.Terms {
+		fields[d] = strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	fields[len(p.Terms)] = strconv.FormatFloat(value, 'g', -1, 64)
+	line := strings.Join(fields, `,`) + "\n"
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.err == nil {
+		_, t.err = io.WriteString(t.w, line)
+	}
+	return value
+}
+
+// Err returns the error of the first failed write to the trace,
+// if any
+func (t *TracedObjective) Err() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.err
+}
@@ -0,0 +1,45 @@
+package simplex
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New(`write failed`)
+}
+
+func TestTraced(t *testing.T) {
+	var buf bytes.Buffer
+	obj := &countingObjective{}
+	traced := Traced(obj, &buf)
+	assert.Equal(t, 0.0, traced.Evaluate(&Point{Dims: 2, Terms: []float64{3, 4}}))
+	assert.Equal(t, 2.0, traced.Evaluate(&Point{Dims: 2, Terms: []float64{2, 5}}))
+	assert.Equal(t, "3,4,0\n2,5,2\n", buf.String())
+	assert.NoError(t, traced.Err())
+
+	// Every evaluation of an optimization is traced
+	buf.Reset()
+	obj = &countingObjective{}
+	opts := seededOptions(1)
+	opts.MaxIters = 20
+	result, err := OptimizeObjective(Traced(obj, &buf), 2, opts)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Equal(t, obj.calls, len(lines))
+	assert.Equal(t, result.Evaluations, len(lines))
+	for _, line := range lines {
+		assert.Equal(t, 3, len(strings.Split(line, `,`)))
+	}
+
+	traced = Traced(obj, failingWriter{})
+	assert.Equal(t, 0.0, traced.Evaluate(&Point{Dims: 2, Terms: []float64{3, 4}}))
+	assert.EqualError(t, traced.Err(), `write failed`)
+}
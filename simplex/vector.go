@@ -0,0 +1,97 @@
+package simplex
+
+import (
+	"fmt"
+	"math"
+)
+
+// checkDims panics if p and q do not have the same dimension.
+// op names the operation in the panic message.
+func checkDims(op string, p, q *Point) {
+	if p.Dims != q.Dims {
+		panic(fmt.Sprintf(`%s: dimension mismatch, %d != %d`, op, p.Dims, q.Dims))
+	}
+}
+
+// Add returns the sum p + q. It panics if the dimensions differ.
+func (p *Point) Add(q *Point) *Point {
+	return AddInto(NewPoint(p.Dims), p, q)
+}
+
+// Sub returns the difference p - q. It panics if the dimensions
+// differ.
+func (p *Point) Sub(q *Point) *Point {
+	return SubInto(NewPoint(p.Dims), p, q)
+}
+
+// Scale returns p with every term multiplied by scalar
+func (p *Point) Scale(scalar float64) *Point {
+	return ScaleInto(NewPoint(p.Dims), p, scalar)
+}
+
+// AddInto stores a + b in dst and returns dst. dst may be a or
+// b. It panics if the dimensions differ.
+func AddInto(dst, a, b *Point) *Point {
+	checkDims(`AddInto`, a, b)
+	checkDims(`AddInto`, dst, a)
+	for d := range dst.Terms {
+		dst.Terms[d] = a.Terms[d] + b.Terms[d]
+	}
+	return dst
+}
+
+// SubInto stores a - b in dst and returns dst. dst may be a or
+// b. It panics if the dimensions differ.
+func SubInto(dst, a, b *Point) *Point {
+	checkDims(`SubInto`, a, b)
+	checkDims(`SubInto`, dst, a)
+	for d := range dst.Terms {
+		dst.Terms[d] = a.Terms[d] - b.Terms[d]
+	}
+	return dst
+}
+
+// ScaleInto stores p multiplied by scalar in dst and returns
+// dst. dst may be p. It panics if the dimensions differ.
+func ScaleInto(dst, p *Point, scalar float64) *Point {
+	checkDims(`ScaleInto`, dst, p)
+	for d := range dst.Terms {
+		dst.Terms[d] = p.Terms[d] * scalar
+	}
+	return dst
+}
+
+// lerpInto stores from + coeff(to - from) in dst and returns
+// dst. dst may be from or to. This is the point reached by
+// moving from towards to, scaling their distance by coeff.
+func lerpInto(dst, from, to *Point, coeff float64) *Point {
+	checkDims(`lerpInto`, from, to)
+	checkDims(`lerpInto`, dst, from)
+	for d := range dst.Terms {
+		dst.Terms[d] = from.Terms[d] + coeff*(to.Terms[d]-from.Terms[d])
+	}
+	return dst
+}
+
+// Dot returns the dot product of p and q. It panics if the
+// dimensions differ.
+func (p *Point) Dot(q *Point) float64 {
+	checkDims(`Dot`, p, q)
+	sum := 0.0
+	for d, v := range p.Terms {
+		sum += v * q.Terms[d]
+	}
+	return sum
+}
+
+// Norm returns the Euclidean length of p
+func (p *Point) Norm() float64 {
+	return math.Sqrt(p.Dot(p))
+}
+
+// Distance returns the Euclidean distance between p and q. It
+// panics if the dimensions differ.
+func (p *Point) Distance(q *Point) float64 {
+	checkDims(`Distance`, p, q)
+	return p.Sub(q).Norm()
+}
@@ -0,0 +1,82 @@
+package simplex
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+)
+
+func TestPointArithmetic(t *testing.T) {
+	p := &Point{Dims: 3, Terms: []float64{1, 2, 2}}
+	q := &Point{Dims: 3, Terms: []float64{4, -2, 0}}
+	tests := []struct {
+		name     string
+		actual   interface{}
+		expected interface{}
+	}{
+		{`Add`, p.Add(q).Terms, []float64{5, 0, 2}},
+		{`Sub`, p.Sub(q).Terms, []float64{-3, 4, 2}},
+		{`Scale`, p.Scale(-2).Terms, []float64{-2, -4, -4}},
+		{`Scale by zero`, p.Scale(0).Terms, []float64{0, 0, 0}},
+		{`Dot`, p.Dot(q), 0.0},
+		{`Dot with self`, p.Dot(p), 9.0},
+		{`Norm`, p.Norm(), 3.0},
+		{`Norm of zero`, NewPoint(3).Norm(), 0.0},
+		{`Distance`, p.Distance(q), math.Sqrt(29)},
+		{`Distance to self`, p.Distance(p), 0.0},
+	}
+	for _, test := range tests {
+		if !assert.Equal(t, test.expected, test.actual) {
+			t.Logf(`failed: %s`, test.name)
+		}
+	}
+	// The operands are never modified
+	assert.Equal(t, []float64{1, 2, 2}, p.Terms)
+	assert.Equal(t, []float64{4, -2, 0}, q.Terms)
+}
+
+func TestPointArithmeticDimensionMismatch(t *testing.T) {
+	p := NewPoint(2)
+	q := NewPoint(3)
+	assert.Panics(t, func() { p.Add(q) })
+	assert.Panics(t, func() { p.Sub(q) })
+	assert.Panics(t, func() { p.Dot(q) })
+	assert.Panics(t, func() { p.Distance(q) })
+}
+
+func TestContractPoint(t *testing.T) {
+	center := &Point{Dims: 2, Terms: []float64{0, 2}}
+	p := &Point{Dims: 2, Terms: []float64{4, 4}}
+	assert.Equal(t, []float64{2, 3}, ContractPoint(center, p, 0.5).Terms)
+}
+
+func TestArithmeticInto(t *testing.T) {
+	a := &Point{Dims: 2, Terms: []float64{1, 2}}
+	b := &Point{Dims: 2, Terms: []float64{3, 5}}
+	dst := NewPoint(2)
+	assert.True(t, dst == AddInto(dst, a, b))
+	assert.Equal(t, []float64{4, 7}, dst.Terms)
+	assert.Equal(t, []float64{-2, -3}, SubInto(dst, a, b).Terms)
+	assert.Equal(t, []float64{2, 4}, ScaleInto(dst, a, 2).Terms)
+
+	// The destination may alias an operand
+	AddInto(a, a, b)
+	assert.Equal(t, []float64{4, 7}, a.Terms)
+	SubInto(b, a, b)
+	assert.Equal(t, []float64{1, 2}, b.Terms)
+	ScaleInto(a, a, 0.5)
+	assert.Equal(t, []float64{2, 3.5}, a.Terms)
+
+	assert.Panics(t, func() { AddInto(NewPoint(3), a, b) })
+	assert.Panics(t, func() { ScaleInto(NewPoint(1), a, 1) })
+}
+
+func BenchmarkReflectPoint(b *testing.B) {
+	center := NewPoint(10)
+	p := &Point{Dims: 10, Terms: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ReflectPoint(center, p, 1)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/blake-wilson/simplex-optimizer/simplex"
+)
+
+// writeSimplexSVG writes a 2-D simplex to w as an SVG image laid
+// out like drawSimplex. If path is not empty, the trajectory it
+// traces is drawn beneath the simplex in the colors used by
+// drawTrajectory, and the simplex and path are scaled together
+// so that both fit inside the margin. If opts is nil,
+// DefaultDrawOptions are used.
+func writeSimplexSVG(w io.Writer, s *simplex.Simplex, path []*simplex.Point, opts *DrawOptions) error {
+	if s.Dimension != 2 {
+		return fmt.Errorf(`writeSimplexSVG: simplex has %d dimensions, expected 2`, s.Dimension)
+	}
+	if opts == nil {
+		opts = DefaultDrawOptions()
+	}
+	// Gather the path and the simplex into one simplex so that
+	// they share a coordinate transform
+	all := simplex.NewSimplex(2)
+	all.Points = append(append(all.Points, path...), s.Points...)
+	imgPoints := fitToImage(all, float64(opts.Width), float64(opts.Height), opts.Margin)
+	pathPoints, simplexPoints := imgPoints[:len(path)], imgPoints[len(path):]
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		opts.Width, opts.Height, opts.Width, opts.Height)
+	if opts.Background != nil && !isTransparent(opts.Background) {
+		fmt.Fprintf(&b, `<rect width="100%%" height="100%%"%s/>`+"\n", svgPaint(`fill`, opts.Background))
+	}
+	for i := 1; i < len(pathPoints); i++ {
+		from, to := pathPoints[i-1], pathPoints[i]
+		c := heatColor(float64(len(pathPoints)-1-i), 0, float64(len(pathPoints)-1))
+		fmt.Fprintf(&b, `<line x1="%g" y1="%g" x2="%g" y2="%g"%s stroke-width="3"/>`+"\n",
+			from.Terms[0], from.Terms[1], to.Terms[0], to.Terms[1], svgPaint(`stroke`, c))
+	}
+	coords := make([]string, len(simplexPoints))
+	for i, p := range simplexPoints {
+		coords[i] = fmt.Sprintf(`%g,%g`, p.Terms[0], p.Terms[1])
+	}
+	fmt.Fprintf(&b, `<polygon points="%s"%s%s stroke-width="%g"/>`+"\n", strings.Join(coords, ` `),
+		svgPaint(`fill`, opts.FillColor), svgPaint(`stroke`, opts.StrokeColor), opts.LineWidth)
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// svgPaint returns the SVG attributes painting attr, such as
+// fill or stroke, with c. Colors which are nil or fully
+// transparent are written as none.
+func svgPaint(attr string, c color.Color) string {
+	if c == nil {
+		return fmt.Sprintf(` %s="none"`, attr)
+	}
+	if isTransparent(c) {
+		return fmt.Sprintf(` %s="none"`, attr)
+	}
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	paint := fmt.Sprintf(` %s="#%02x%02x%02x"`, attr, n.R, n.G, n.B)
+	if n.A != 0xff {
+		paint += fmt.Sprintf(` %s-opacity="%g"`, attr, float64(n.A)/0xff)
+	}
+	return paint
+}
+
+// isTransparent reports whether c has zero alpha
+func isTransparent(c color.Color) bool {
+	_, _, _, a := c.RGBA()
+	return a == 0
+}
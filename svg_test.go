@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+	"github.com/blake-wilson/simplex-optimizer/simplex"
+)
+
+func TestWriteSimplexSVG(t *testing.T) {
+	s := simplex.NewSimplex(2)
+	s.Points = []*simplex.Point{
+		{Dims: 2, Terms: []float64{0, 0}},
+		{Dims: 2, Terms: []float64{1, 0}},
+		{Dims: 2, Terms: []float64{0, 1}},
+	}
+	opts := DefaultDrawOptions()
+	opts.Width, opts.Height = 120, 120
+	opts.Margin = 10
+	var buf bytes.Buffer
+	assert.NoError(t, writeSimplexSVG(&buf, s, nil, opts))
+
+	svg := buf.String()
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="120" height="120"`))
+	assert.True(t, strings.Contains(svg,
+		`<polygon points="10,10 110,10 10,110" fill="#44ff44" stroke="#ff0000" stroke-width="5"/>`))
+	// The default background is transparent
+	assert.False(t, strings.Contains(svg, `<rect`))
+	assert.False(t, strings.Contains(svg, `<line`))
+	assert.True(t, strings.HasSuffix(svg, "</svg>\n"))
+
+	assert.Error(t, writeSimplexSVG(&buf, simplex.NewSimplex(3), nil, nil))
+}
+
+func TestWriteSimplexSVGPath(t *testing.T) {
+	s := simplex.NewSimplex(2)
+	s.Points = []*simplex.Point{
+		{Dims: 2, Terms: []float64{0, 0}},
+		{Dims: 2, Terms: []float64{1, 0}},
+		{Dims: 2, Terms: []float64{0, 1}},
+	}
+	path := []*simplex.Point{
+		{Dims: 2, Terms: []float64{4, 4}},
+		{Dims: 2, Terms: []float64{2, 2}},
+		{Dims: 2, Terms: []float64{0, 0}},
+	}
+	var buf bytes.Buffer
+	opts := DefaultDrawOptions()
+	opts.Background = color.White
+	assert.NoError(t, writeSimplexSVG(&buf, s, path, opts))
+	assert.Equal(t, 2, strings.Count(buf.String(), `<line`))
+	assert.True(t, strings.Contains(buf.String(), `<rect width="100%" height="100%" fill="#ffffff"/>`))
+}
+
+func TestSVGPaint(t *testing.T) {
+	assert.Equal(t, ` fill="#123456"`, svgPaint(`fill`, color.RGBA{0x12, 0x34, 0x56, 0xff}))
+	assert.Equal(t, ` stroke="none"`, svgPaint(`stroke`, nil))
+	assert.Equal(t, ` fill="#ff0000" fill-opacity="0.2"`,
+		svgPaint(`fill`, color.NRGBA{0xff, 0x00, 0x00, 0x33}))
+}
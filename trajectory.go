@@ -0,0 +1,39 @@
+package main
+
+import (
+	"image"
+
+	"github.com/blake-wilson/simplex-optimizer/simplex"
+	"github.com/llgcode/draw2d/draw2dimg"
+)
+
+// drawTrajectory renders the path traced by a sequence of 2-D
+// points, such as simplex.Result.Path, over the path's bounding
+// box padded by heatmapPadding on each side. The path is drawn
+// from red at its first point to blue at its last. nil is
+// returned if the path is empty or not 2-D.
+func drawTrajectory(path []*simplex.Point) *image.RGBA {
+	if len(path) == 0 || path[0].Dims != 2 {
+		return nil
+	}
+	s := simplex.NewSimplex(2)
+	s.Points = path
+	imgPoints := boundsToImage(path, paddedBounds(s))
+
+	size := int(defaultImageSize)
+	dest := image.NewRGBA(image.Rect(0, 0, size, size))
+	gc := draw2dimg.NewGraphicContext(dest)
+	gc.SetLineWidth(3)
+
+	var prev *simplex.Point
+	for i, ip := range imgPoints {
+		if prev != nil {
+			gc.SetStrokeColor(heatColor(float64(len(path)-1-i), 0, float64(len(path)-1)))
+			gc.MoveTo(prev.Terms[0], prev.Terms[1])
+			gc.LineTo(ip.Terms[0], ip.Terms[1])
+			gc.Stroke()
+		}
+		prev = ip
+	}
+	return dest
+}
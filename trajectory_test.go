@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Workiva/stretchr/assert"
+	"github.com/blake-wilson/simplex-optimizer/simplex"
+)
+
+func TestDrawTrajectory(t *testing.T) {
+	eval := func(p *simplex.Point) float64 { return p.Dot(p) }
+	opts := simplex.DefaultOptions()
+	opts.RecordPath = true
+	opts.MaxIters = 50
+	result, err := simplex.OptimizeWithOptions(eval, 2, opts)
+	assert.NoError(t, err)
+
+	img := drawTrajectory(result.Path)
+	assert.NotNil(t, img)
+	size := int(defaultImageSize)
+	assert.Equal(t, size, img.Bounds().Dx())
+	assert.Equal(t, size, img.Bounds().Dy())
+
+	assert.Nil(t, drawTrajectory(nil))
+	assert.Nil(t, drawTrajectory([]*simplex.Point{simplex.NewPoint(3)}))
+}